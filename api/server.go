@@ -19,6 +19,31 @@ type BigModelChoices struct {
 	Index        int             `json:"index,omitempty"`
 	Message      BigModelMessage `json:"message,omitempty"`
 }
+type BigModelReq struct {
+	Model          string                  `json:"model"`
+	Messages       []BigModelReqMessage    `json:"messages"`
+	ResponseFormat *BigModelResponseFormat `json:"response_format,omitempty"`
+}
+type BigModelResponseFormat struct {
+	Type       string              `json:"type"`
+	JsonSchema *BigModelJsonSchema `json:"json_schema,omitempty"`
+}
+type BigModelJsonSchema struct {
+	Name   string                 `json:"name"`
+	Schema map[string]interface{} `json:"schema"`
+}
+type BigModelReqMessage struct {
+	Role    string               `json:"role"`
+	Content []BigModelReqContent `json:"content"`
+}
+type BigModelReqContent struct {
+	Type     string               `json:"type"`
+	Text     string               `json:"text,omitempty"`
+	ImageUrl *BigModelReqImageUrl `json:"image_url,omitempty"`
+}
+type BigModelReqImageUrl struct {
+	Url string `json:"url"`
+}
 type BigModelUsage struct {
 	CompletionTokens int `json:"completion_tokens,omitempty"`
 	PromptTokens     int `json:"prompt_tokens,omitempty"`
@@ -28,29 +53,95 @@ type BigModelUsage struct {
 type Response struct {
 	Message string      `json:"message" dc:"api tip"`
 	Data    interface{} `json:"data"    dc:"api result"`
+	Detail  interface{} `json:"detail,omitempty" dc:"error detail"`
 }
 
+// OcrReq 也用于 /ocr/stream, 以 SSE 推送 started, token 和 result 或 error 事件
 type OcrReq struct {
-	g.Meta   `path:"/ocr" method:"post"`
-	Content  string `v:"required" json:"content"`
-	Platform string `json:"platform" d:"bigmodel"`
-	Model    string `json:"model"`
+	g.Meta       `path:"/ocr" method:"post"`
+	Content      string `v:"required" json:"content"`
+	Platform     string `json:"platform" d:"bigmodel"`
+	Model        string `json:"model"`
+	Mode         string `json:"mode" d:"number" v:"in:number,text,all" dc:"number returns the first number, text returns the full transcription, all returns every number"`
+	LanguageTags bool   `json:"language_tags" dc:"text mode only, tag every line with its language"`
+	// 以下参数只在 number 模式下生效, charset 不是 digits 或设置了其他任意一项时按验证码模式识别
+	Charset       string `json:"charset" d:"digits" v:"in:digits,alphanumeric,letters"`
+	CaseSensitive bool   `json:"case_sensitive" dc:"keep letter case, otherwise letters are returned in upper case"`
+	Length        int    `json:"length" v:"min:0" dc:"exact code length"`
+	MinLength     int    `json:"min_length" v:"min:0"`
+	MaxLength     int    `json:"max_length" v:"min:0"`
+	Pattern       string `json:"pattern" dc:"regular expression the whole code must match"`
 }
 
 type OcrRes struct {
-	Content string `json:"content" dc:"ocr result"`
+	Content  string       `json:"content" dc:"ocr result"`
+	Text     string       `json:"text,omitempty" dc:"plain text transcription"`
+	Markdown string       `json:"markdown,omitempty" dc:"transcription keeping headings, paragraphs, lists and tables"`
+	Lines    []TextLine   `json:"lines,omitempty" dc:"lines in reading order, returned when language_tags is set"`
+	Numbers  []NumberInfo `json:"numbers,omitempty" dc:"all mode only, every number in reading order"`
+	Warnings []string     `json:"warnings,omitempty" dc:"validation warnings"`
 }
 
+// NumberInfo all 模式下识别出的一个数字
+type NumberInfo struct {
+	Value      string  `json:"value"`      // 印刷的数字, 去掉了空格, 保留小数点和千分位
+	Label      string  `json:"label"`      // 数字旁边说明其含义的文字, 例如 "Tracking No."
+	Pos        []Point `json:"pos"`        // 在原图中的大致位置, 顺序为左上、右上、右下、左下
+	Confidence float64 `json:"confidence"` // 0-1
+}
+
+// TextLine 全文识别中的一行文字及其语言
+type TextLine struct {
+	Text     string `json:"text"`
+	Language string `json:"language"` // BCP 47 语言标签, 例如 en, zh-Hans
+}
+
+// OutputProfile 识别完成后统一应用的输出格式, 为空时保持模板的默认格式
+type OutputProfile struct {
+	DateFormat string `json:"date_format" v:"in:iso,dd/mm/yyyy,mm/dd/yyyy,yyyy.mm.dd,yyyymmdd" dc:"output layout of date fields, iso is yyyy-mm-dd"`
+	NameCase   string `json:"name_case" v:"in:upper,lower,title" dc:"letter case of name fields, native-script names are kept as printed"`
+	SexFormat  string `json:"sex_format" v:"in:letter,word,iso5218" dc:"letter is M/F/X, word is male/female/unspecified, iso5218 is 1/2/9 and 0 when unknown"`
+}
+
+// OcrPassportReq 也用于 /ocr/passport/stream, 除 /ocr/stream 的事件外还推送 field 事件; field 事件只有护照流式接口推送
 type OcrPassportReq struct {
-	g.Meta   `path:"/ocr/passport" method:"post"`
-	Content  string `json:"content"`
-	Url      string `json:"url"`
-	Platform string `json:"platform"`
-	Model    string `json:"model"`
+	g.Meta    `path:"/ocr/passport" method:"post"`
+	Content   string `json:"content"`
+	Url       string `json:"url"`
+	Platform  string `json:"platform"`
+	Model     string `json:"model"`
+	Positions bool   `json:"positions" dc:"return the bounding polygon of every field"`
+	Schema    string `json:"schema" d:"compact" v:"in:compact,full" dc:"full also returns document type, place of birth, authority, personal number, middle names, native-script names, the MRZ lines and alpha-2 and ICAO country codes"`
+	OutputProfile
+}
+
+// ValidityInfo 由识别出的日期推算的有效性, 缺少对应日期时字段为空
+type ValidityInfo struct {
+	Expired         *bool `json:"expired,omitempty"`
+	DaysUntilExpiry *int  `json:"days_until_expiry,omitempty"` // 已过期时为负数
+	Age             *int  `json:"age,omitempty"`
+	Over18          *bool `json:"over_18,omitempty"`
+	Over21          *bool `json:"over_21,omitempty"`
+	Consistent      bool  `json:"consistent"` // 出生、签发、有效期的先后顺序和有效期长度合理
+}
+
+// TranslationInfo 一个字段的翻译结果, 译文同时写入识别结果的字段
+type TranslationInfo struct {
+	Field      string `json:"field"`
+	Original   string `json:"original"`
+	Translated string `json:"translated,omitempty"`
+	Language   string `json:"language"`
+	Status     string `json:"status"`            // translated, failed 或 skipped (原文为空)
+	Backend    string `json:"backend,omitempty"` // glossary, llm 或 provider
+	Cached     bool   `json:"cached,omitempty"`
+	Error      string `json:"error,omitempty"`
 }
 
 type OcrPassportRes struct {
-	PassportInfo *PassportInfo `json:"passport_info"    dc:"api result"`
+	PassportInfo *PassportInfo  `json:"passport_info"    dc:"api result"`
+	Positions    []KeyValueInfo `json:"positions,omitempty" dc:"field positions in original image pixels"`
+	Validity     *ValidityInfo  `json:"validity,omitempty" dc:"derived from the recognized dates"`
+	Warnings     []string       `json:"warnings,omitempty" dc:"validation warnings"`
 }
 
 type PassportInfo struct {
@@ -61,21 +152,39 @@ type PassportInfo struct {
 	IssueDate   string `json:"issue_date"`
 	ExpiryDate  string `json:"expiry_date"`
 	Sex         string `json:"sex"`
-	Nationality string `json:"nationality"`
-	CountryCode string `json:"country_code"`
+	Nationality string `json:"nationality"`  // ISO 3166-1 alpha-3
+	CountryCode string `json:"country_code"` // 签发国, ISO 3166-1 alpha-3
+	// 以下字段只在 schema 为 full 时返回
+	DocumentType      string   `json:"document_type,omitempty"`
+	PlaceOfBirth      string   `json:"place_of_birth,omitempty"`
+	IssuingAuthority  string   `json:"issuing_authority,omitempty"`
+	PersonalNumber    string   `json:"personal_number,omitempty"`
+	MiddleNames       string   `json:"middle_names,omitempty"`
+	SurnameNative     string   `json:"surname_native,omitempty"` // 本国文字姓名, 只有本国文字时 surname 和 givename 按 ICAO 9303 转写
+	GivenameNative    string   `json:"givename_native,omitempty"`
+	Mrz               []string `json:"mrz,omitempty"`
+	CountryAlpha2     string   `json:"country_alpha2,omitempty"`
+	NationalityAlpha2 string   `json:"nationality_alpha2,omitempty"`
+	NationalityIcao   string   `json:"nationality_icao,omitempty"`
 }
 
 type OcrDrivingLicenseReq struct {
-	g.Meta   `path:"/ocr/driving-license" method:"post"`
-	Content  string `json:"content"`
-	Url      string `json:"url"`
-	Platform string `json:"platform"`
-	Model    string `json:"model"`
-	Language string `json:"language" d:"en"`
+	g.Meta    `path:"/ocr/driving-license" method:"post"`
+	Content   string `json:"content"`
+	Url       string `json:"url"`
+	Platform  string `json:"platform"`
+	Model     string `json:"model"`
+	Language  string `json:"language" d:"en"`
+	Positions bool   `json:"positions" dc:"return the bounding polygon of every field"`
+	OutputProfile
 }
 
 type OcrDrivingLicenseRes struct {
 	DrivingLicenseInfo *DriverLicenseInfo `json:"driving_license_info"    dc:"api result"`
+	Positions          []KeyValueInfo     `json:"positions,omitempty" dc:"field positions in original image pixels"`
+	Validity           *ValidityInfo      `json:"validity,omitempty" dc:"derived from the recognized dates"`
+	Translations       []TranslationInfo  `json:"translations,omitempty" dc:"original value and status of every translated field"`
+	Warnings           []string           `json:"warnings,omitempty" dc:"validation warnings"`
 }
 
 // Point 定义一个二维坐标点
@@ -135,7 +244,8 @@ type DrivingLicenseAPIResponse struct {
 }
 
 type DriverLicenseInfo struct {
-	Name          string `json:"name"`
+	Name          string `json:"name"`                  // 拉丁字母姓名, 只有本国文字时按 ICAO 9303 转写
+	NameNative    string `json:"name_native,omitempty"` // 本国文字姓名
 	LicenseNumber string `json:"license_number"`
 	DateOfBirth   string `json:"date_of_birth"`
 	IssueDate     string `json:"issue_date"`
@@ -144,3 +254,282 @@ type DriverLicenseInfo struct {
 	Class         string `json:"class"`
 	Gender        string `json:"gender"`
 }
+
+type OcrIdCardReq struct {
+	g.Meta   `path:"/ocr/id-card" method:"post"`
+	Content  string `json:"content"`
+	Url      string `json:"url"`
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+	Side     string `json:"side" d:"front" v:"in:front,back" dc:"front: 人像面, back: 国徽面"`
+}
+
+type OcrIdCardRes struct {
+	IdCardInfo *IdCardInfo   `json:"id_card_info"    dc:"api result"`
+	Validity   *ValidityInfo `json:"validity,omitempty" dc:"derived from the birth date or the valid period"`
+	Warnings   []string      `json:"warnings,omitempty" dc:"validation warnings"`
+}
+
+// IdCardInfo 居民身份证识别结果, 人像面和国徽面分别填充各自的字段
+type IdCardInfo struct {
+	Name           string `json:"name"`            // 姓名
+	Sex            string `json:"sex"`             // 性别
+	Ethnicity      string `json:"ethnicity"`       // 民族
+	BirthDate      string `json:"birth_date"`      // 出生日期
+	Address        string `json:"address"`         // 住址
+	IdNumber       string `json:"id_number"`       // 公民身份号码
+	IssueAuthority string `json:"issue_authority"` // 签发机关
+	ValidPeriod    string `json:"valid_period"`    // 有效期限
+}
+
+type OcrVehicleLicenseReq struct {
+	g.Meta   `path:"/ocr/vehicle-license" method:"post"`
+	Content  string `json:"content"`
+	Url      string `json:"url"`
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+}
+
+type OcrVehicleLicenseRes struct {
+	VehicleLicenseInfo *VehicleLicenseInfo `json:"vehicle_license_info"    dc:"api result"`
+}
+
+// VehicleLicenseInfo 机动车行驶证识别结果
+type VehicleLicenseInfo struct {
+	PlateNumber  string `json:"plate_number"`  // 号牌号码
+	VehicleType  string `json:"vehicle_type"`  // 车辆类型
+	Owner        string `json:"owner"`         // 所有人
+	Address      string `json:"address"`       // 住址
+	UseCharacter string `json:"use_character"` // 使用性质
+	Model        string `json:"model"`         // 品牌型号
+	Vin          string `json:"vin"`           // 车辆识别代号
+	EngineNumber string `json:"engine_number"` // 发动机号码
+	RegisterDate string `json:"register_date"` // 注册日期
+	IssueDate    string `json:"issue_date"`    // 发证日期
+}
+
+type OcrBankCardReq struct {
+	g.Meta   `path:"/ocr/bank-card" method:"post"`
+	Content  string `json:"content"`
+	Url      string `json:"url"`
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+}
+
+type OcrBankCardRes struct {
+	BankCardInfo *BankCardInfo `json:"bank_card_info"    dc:"api result"`
+	Warnings     []string      `json:"warnings,omitempty" dc:"validation warnings"`
+}
+
+// BankCardInfo 银行卡识别结果
+type BankCardInfo struct {
+	CardNumber     string `json:"card_number"`     // 卡号, 只包含数字
+	IssuingBank    string `json:"issuing_bank"`    // 发卡行
+	CardType       string `json:"card_type"`       // 卡类型: debit, credit, prepaid
+	Network        string `json:"network"`         // 卡组织, 由 BIN 号段得出
+	ExpiryDate     string `json:"expiry_date"`     // 有效期, MM/YY
+	CardholderName string `json:"cardholder_name"` // 持卡人姓名
+}
+
+type OcrBusinessLicenseReq struct {
+	g.Meta   `path:"/ocr/business-license" method:"post"`
+	Content  string `json:"content"`
+	Url      string `json:"url"`
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+}
+
+type OcrBusinessLicenseRes struct {
+	BusinessLicenseInfo *BusinessLicenseInfo `json:"business_license_info"    dc:"api result"`
+	Warnings            []string             `json:"warnings,omitempty" dc:"validation warnings"`
+}
+
+// BusinessLicenseInfo 营业执照识别结果
+type BusinessLicenseInfo struct {
+	CreditCode          string `json:"credit_code"`          // 统一社会信用代码
+	CompanyName         string `json:"company_name"`         // 名称
+	CompanyType         string `json:"company_type"`         // 类型
+	LegalRepresentative string `json:"legal_representative"` // 法定代表人
+	RegisteredCapital   string `json:"registered_capital"`   // 注册资本
+	EstablishmentDate   string `json:"establishment_date"`   // 成立日期
+	BusinessTerm        string `json:"business_term"`        // 营业期限
+	Address             string `json:"address"`              // 住所
+	BusinessScope       string `json:"business_scope"`       // 经营范围
+}
+
+type OcrInvoiceReq struct {
+	g.Meta   `path:"/ocr/invoice" method:"post"`
+	Content  string `json:"content"`
+	Url      string `json:"url"`
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+}
+
+type OcrInvoiceRes struct {
+	InvoiceInfo *InvoiceInfo `json:"invoice_info"    dc:"api result"`
+	Warnings    []string     `json:"warnings,omitempty" dc:"arithmetic check warnings"`
+}
+
+// InvoiceInfo 增值税发票或收据识别结果, 金额保持票面上的写法
+type InvoiceInfo struct {
+	InvoiceType   string        `json:"invoice_type"`   // 票据类型: vat_special, vat_normal, receipt
+	InvoiceCode   string        `json:"invoice_code"`   // 发票代码
+	InvoiceNumber string        `json:"invoice_number"` // 发票号码
+	InvoiceDate   string        `json:"invoice_date"`   // 开票日期
+	BuyerName     string        `json:"buyer_name"`     // 购买方名称
+	BuyerTaxId    string        `json:"buyer_tax_id"`   // 购买方纳税人识别号
+	SellerName    string        `json:"seller_name"`    // 销售方名称
+	SellerTaxId   string        `json:"seller_tax_id"`  // 销售方纳税人识别号
+	Items         []InvoiceItem `json:"items"`          // 明细
+	TotalAmount   string        `json:"total_amount"`   // 合计金额 (不含税)
+	TotalTax      string        `json:"total_tax"`      // 合计税额
+	TotalWithTax  string        `json:"total_with_tax"` // 价税合计
+}
+
+// InvoiceItem 发票明细行
+type InvoiceItem struct {
+	Name      string `json:"name"`       // 项目名称
+	Quantity  string `json:"quantity"`   // 数量
+	UnitPrice string `json:"unit_price"` // 单价
+	Amount    string `json:"amount"`     // 金额
+	TaxRate   string `json:"tax_rate"`   // 税率
+	Tax       string `json:"tax"`        // 税额
+}
+
+type OcrExtractReq struct {
+	g.Meta      `path:"/ocr/extract" method:"post"`
+	Content     string                 `v:"required" json:"content"`
+	Url         string                 `json:"url"`
+	Platform    string                 `json:"platform"`
+	Model       string                 `json:"model"`
+	Schema      map[string]interface{} `json:"schema" dc:"JSON Schema of the result object"`
+	Fields      []ExtractField         `json:"fields" dc:"simple field list, used when schema is empty"`
+	Instruction string                 `json:"instruction" dc:"extra instruction appended to the prompt"`
+}
+
+// ExtractField 简单字段列表中的一项, 会被转换为 JSON Schema 的一个属性
+type ExtractField struct {
+	Name        string `v:"required" json:"name"`
+	Type        string `json:"type" d:"string" v:"in:string,number,integer,boolean"`
+	Description string `json:"description"`
+	Format      string `json:"format" dc:"JSON Schema format, e.g. date, date-time, email"`
+	Pattern     string `json:"pattern" dc:"regular expression the value must match"`
+	Required    bool   `json:"required"`
+}
+
+type OcrExtractRes struct {
+	Result map[string]interface{} `json:"result" dc:"object validated against the schema"`
+}
+
+type OcrDocumentReq struct {
+	g.Meta    `path:"/ocr/document/{type}" method:"post"`
+	Type      string `v:"required" json:"type" in:"path" dc:"document template type, e.g. passport"`
+	Content   string `v:"required" json:"content"`
+	Url       string `json:"url"`
+	Platform  string `json:"platform"`
+	Model     string `json:"model"`
+	Language  string `json:"language" d:"en" dc:"prompt language and target language of translated fields"`
+	Positions bool   `json:"positions" dc:"return the bounding polygon of every field"`
+	Schema    string `json:"schema" d:"compact" v:"in:compact,full" dc:"full also returns the extended fields of the template"`
+	OutputProfile
+}
+
+type OcrDocumentRes struct {
+	DocumentType string            `json:"document_type"`
+	Fields       map[string]string `json:"fields"    dc:"api result"`
+	Positions    []KeyValueInfo    `json:"positions,omitempty" dc:"field positions in original image pixels"`
+	Validity     *ValidityInfo     `json:"validity,omitempty" dc:"derived from the dates whose template field has a date_kind"`
+	Translations []TranslationInfo `json:"translations,omitempty" dc:"original value and status of every translated field"`
+	Warnings     []string          `json:"warnings,omitempty" dc:"validation warnings"`
+}
+
+type OcrTableReq struct {
+	g.Meta   `path:"/ocr/table" method:"post"`
+	Content  string `v:"required" json:"content"`
+	Url      string `json:"url"`
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+}
+
+type OcrTableRes struct {
+	Tables []TableInfo `json:"tables" dc:"tables in reading order"`
+}
+
+// OcrTableDownloadReq 以 CSV 或 XLSX 文件下载识别出的表格
+type OcrTableDownloadReq struct {
+	Content  string `v:"required" json:"content"`
+	Url      string `json:"url"`
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+	Format   string `json:"format" d:"csv" v:"in:csv,xlsx"`
+	Table    int    `json:"table" d:"0" v:"min:0" dc:"csv only, index of the table to download"`
+}
+
+// TableInfo 识别出的一个表格
+// 合并单元格的值会填充到它覆盖的每个单元格中, 单元格内的多行文字以 \n 分隔
+type TableInfo struct {
+	Title      string     `json:"title"`
+	HeaderRows int        `json:"header_rows"` // 前几行是表头
+	Columns    []string   `json:"columns"`     // 每列的表头, 多行表头以 " / " 连接
+	Rows       [][]string `json:"rows"`        // 包含表头在内的所有行, 每行列数相同
+}
+
+type OcrClassifyReq struct {
+	g.Meta   `path:"/ocr/classify" method:"post"`
+	Content  string `v:"required" json:"content"`
+	Url      string `json:"url"`
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+}
+
+type OcrClassifyRes struct {
+	*ClassifyInfo
+}
+
+// ClassifyInfo 文档分类结果
+type ClassifyInfo struct {
+	DocumentType string  `json:"document_type"` // passport, id_card, driving_license, vehicle_license, business_license, invoice, bank_card, other
+	Country      string  `json:"country"`       // 签发国家, ISO 3166-1 alpha-3, 无法判断时为空
+	Side         string  `json:"side"`          // front, back, 单页文档为 front
+	Confidence   float64 `json:"confidence"`    // 0-1
+}
+
+type OcrAutoReq struct {
+	g.Meta    `path:"/ocr/auto" method:"post"`
+	Content   string   `v:"required" json:"content"`
+	Url       string   `json:"url"`
+	Platform  string   `json:"platform"`
+	Model     string   `json:"model"`
+	Language  string   `json:"language" d:"en" dc:"driving licence only, target language of translated fields"`
+	Positions bool     `json:"positions" dc:"passport and driving licence only, return the bounding polygon of every field"`
+	Expect    []string `json:"expect" dc:"accepted document types, other types are rejected before extraction"`
+	// 输出格式只作用于护照和驾驶证
+	OutputProfile
+}
+
+type OcrAutoRes struct {
+	Classification *ClassifyInfo     `json:"classification"`
+	Result         interface{}       `json:"result" dc:"result of the matching extractor, empty when no extractor matches; Chinese ID card, vehicle licence and business licence extractors run only when the country is CHN"`
+	Positions      []KeyValueInfo    `json:"positions,omitempty" dc:"field positions in original image pixels"`
+	Validity       *ValidityInfo     `json:"validity,omitempty" dc:"passport, driving licence and ID card only, derived from the recognized dates"`
+	Translations   []TranslationInfo `json:"translations,omitempty" dc:"driving licence only, original value and status of every translated field"`
+	Warnings       []string          `json:"warnings,omitempty" dc:"validation warnings"`
+}
+
+// VerifyAgeReq 最小披露的年龄核验, 响应中不包含任何身份信息
+type VerifyAgeReq struct {
+	g.Meta    `path:"/verify/age" method:"post"`
+	Content   string `v:"required" json:"content" dc:"passport, driving licence or the portrait side of a Chinese ID card"`
+	Url       string `json:"url"`
+	Platform  string `json:"platform"`
+	Model     string `json:"model"`
+	Threshold int    `json:"threshold" d:"18" v:"min:1|max:150" dc:"minimum age in years"`
+}
+
+type VerifyAgeRes struct {
+	Verified     bool    `json:"verified" dc:"the holder is at least threshold years old"`
+	Status       string  `json:"status" dc:"verified, underage or unverifiable when the birth date cannot be read"`
+	DocumentType string  `json:"document_type"`
+	ExpiryStatus string  `json:"expiry_status" dc:"valid, expired or unknown"`
+	Confidence   float64 `json:"confidence" dc:"0-1, lowered when the dates are inconsistent or fail cross-validation, 0 when unverifiable"`
+}
@@ -0,0 +1,30 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// BankCardInfo 识别银行卡, 使用 Luhn 校验卡号并根据 BIN 号段确定卡组织, 日志中的卡号会被脱敏
+func BankCardInfo(ctx context.Context, serv provider.Provider, imageBase64, modelName string) (resp *api.BankCardInfo, warnings []string, err error) {
+	prompt := "Extract the following fields from this bank card image: card_number (digits only), issuing_bank, card_type (debit, credit or prepaid), expiry_date (format MM/YY), cardholder_name. Use an empty string for fields that are not printed on the card. Return as JSON object."
+	var info api.BankCardInfo
+	if err = completeJSON(ctx, serv, imageBase64, modelName, prompt, &info); err != nil {
+		return nil, nil, err
+	}
+
+	info.CardNumber = tool.CardNumberDigits(info.CardNumber)
+	info.Network = tool.CardNetwork(info.CardNumber)
+	if !tool.LuhnValid(info.CardNumber) {
+		warnings = append(warnings, "card number failed luhn check")
+	}
+	if info.Network == "" {
+		warnings = append(warnings, "card network is not recognized")
+	}
+	g.Log().Infof(ctx, "bank card %s, network: %s", tool.MaskCardNumber(info.CardNumber), info.Network)
+	return &info, warnings, nil
+}
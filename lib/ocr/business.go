@@ -0,0 +1,29 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"strings"
+)
+
+// BusinessLicenseInfo 识别营业执照, 并按 GB 32100 校验统一社会信用代码
+func BusinessLicenseInfo(ctx context.Context, serv provider.Provider, imageBase64, modelName string) (resp *api.BusinessLicenseInfo, warnings []string, err error) {
+	prompt := "Extract the following fields from this Chinese business license (营业执照): credit_code (unified social credit code, 18 characters), company_name, company_type, legal_representative, registered_capital, establishment_date (format yyyy.mm.dd), business_term (format yyyy.mm.dd-yyyy.mm.dd, or yyyy.mm.dd-长期), address, business_scope. Keep Chinese text as printed. Return as JSON object."
+	var info api.BusinessLicenseInfo
+	if err = completeJSON(ctx, serv, imageBase64, modelName, prompt, &info); err != nil {
+		return nil, nil, err
+	}
+
+	info.CreditCode = strings.ToUpper(strings.ReplaceAll(info.CreditCode, " ", ""))
+	outputFormat := "2006.01.02"
+	if converted, err := tool.ParseAndFormatDate(info.EstablishmentDate, outputFormat); err == nil {
+		info.EstablishmentDate = converted
+	}
+	info.BusinessTerm = tool.FormatDateRange(info.BusinessTerm, outputFormat)
+	if err = tool.ValidateCreditCode(info.CreditCode); err != nil {
+		warnings = append(warnings, err.Error())
+	}
+	return &info, warnings, nil
+}
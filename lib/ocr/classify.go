@@ -0,0 +1,119 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// 分类支持的文档类型
+const (
+	DocumentPassport        = "passport"
+	DocumentIdCard          = "id_card"
+	DocumentDrivingLicense  = "driving_license"
+	DocumentVehicleLicense  = "vehicle_license"
+	DocumentBusinessLicense = "business_license"
+	DocumentInvoice         = "invoice"
+	DocumentBankCard        = "bank_card"
+	DocumentOther           = "other"
+)
+
+var documentTypes = []interface{}{DocumentPassport, DocumentIdCard, DocumentDrivingLicense, DocumentVehicleLicense, DocumentBusinessLicense, DocumentInvoice, DocumentBankCard, DocumentOther}
+
+var classifyPrompt = "Identify the document in this image. Return a JSON object with: " +
+	"document_type (passport for a passport data page, id_card for a national identity card, driving_license, vehicle_license for a vehicle registration certificate such as the Chinese 行驶证, business_license, invoice for invoices and receipts, bank_card for debit and credit cards, other for anything else), " +
+	"country (the ISO 3166-1 alpha-3 code of the issuing country, or an empty string if it cannot be determined), " +
+	"side (front or back; use front for single-sided documents and passport data pages; for Chinese ID cards the portrait side is front and the national emblem side is back), " +
+	"confidence (a number between 0 and 1)."
+
+var classifySchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"document_type": map[string]interface{}{"type": "string", "enum": documentTypes},
+		"country":       map[string]interface{}{"type": "string"},
+		"side":          map[string]interface{}{"type": "string", "enum": []interface{}{"front", "back"}},
+		"confidence":    map[string]interface{}{"type": "number"},
+	},
+	"required": []interface{}{"document_type", "country", "side", "confidence"},
+}
+
+// ClassifyDocument 识别图片中的文档类型、签发国家和正反面
+func ClassifyDocument(ctx context.Context, serv provider.Provider, imageBase64, modelName string) (resp *api.ClassifyInfo, err error) {
+	content, err := completeSchema(ctx, serv, imageBase64, modelName, classifyPrompt, classifySchema)
+	if err != nil {
+		return nil, err
+	}
+	if err = decodeJSON(content, &resp); err != nil {
+		return nil, err
+	}
+	resp.DocumentType = strings.ToLower(strings.TrimSpace(resp.DocumentType))
+	if !slices.Contains(documentTypes, interface{}(resp.DocumentType)) {
+		resp.DocumentType = DocumentOther
+	}
+	if country, err := tool.LookupCountry(resp.Country); err == nil {
+		resp.Country = country.Code()
+	} else {
+		resp.Country = ""
+	}
+	if resp.Side = strings.ToLower(strings.TrimSpace(resp.Side)); resp.Side != "back" {
+		resp.Side = "front"
+	}
+	resp.Confidence = min(max(resp.Confidence, 0), 1)
+	return resp, nil
+}
+
+// AutoRecognize 先分类再调用对应类型的识别方法
+// 没有对应识别方法的文档只返回分类结果和警告, 类型不在 req.Expect 中时返回错误
+func AutoRecognize(ctx context.Context, serv provider.Provider, req *api.OcrAutoReq) (resp *api.OcrAutoRes, err error) {
+	classification, err := ClassifyDocument(ctx, serv, req.Content, req.Model)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Expect) > 0 && !slices.Contains(req.Expect, classification.DocumentType) {
+		return nil, fmt.Errorf("document is %s, expect %s", classification.DocumentType, strings.Join(req.Expect, " or "))
+	}
+	resp = &api.OcrAutoRes{Classification: classification}
+
+	// 身份证、行驶证和营业执照的识别方法只支持中国的证件, 国家未知时不套用中国证件的校验规则, 只返回分类结果
+	chinese := classification.Country == "CHN"
+	switch {
+	case classification.DocumentType == DocumentPassport:
+		passport, err := PassportInfo(ctx, serv, req.Content, req.Model, DocumentOptions{Positions: req.Positions, Profile: req.OutputProfile})
+		if err != nil {
+			return nil, err
+		}
+		resp.Result, resp.Positions, resp.Validity, resp.Warnings = passport.PassportInfo, passport.Positions, passport.Validity, passport.Warnings
+	case classification.DocumentType == DocumentDrivingLicense:
+		license, err := DrivingLicenseInfo(ctx, serv, req.Content, req.Model, DocumentOptions{Language: req.Language, Positions: req.Positions, Profile: req.OutputProfile})
+		if err != nil {
+			return nil, err
+		}
+		resp.Result, resp.Positions, resp.Validity, resp.Warnings = license.DrivingLicenseInfo, license.Positions, license.Validity, license.Warnings
+		resp.Translations = license.Translations
+	case classification.DocumentType == DocumentIdCard && chinese:
+		idCard, warnings, err := IdCardInfo(ctx, serv, req.Content, req.Model, classification.Side)
+		if err != nil {
+			return nil, err
+		}
+		validity, _, validityWarnings := IdCardValidity(idCard)
+		resp.Result, resp.Validity, resp.Warnings = idCard, validity, append(warnings, validityWarnings...)
+	case classification.DocumentType == DocumentVehicleLicense && chinese:
+		resp.Result, err = VehicleLicenseInfo(ctx, serv, req.Content, req.Model)
+	case classification.DocumentType == DocumentBusinessLicense && chinese:
+		resp.Result, resp.Warnings, err = BusinessLicenseInfo(ctx, serv, req.Content, req.Model)
+	case classification.DocumentType == DocumentInvoice:
+		resp.Result, resp.Warnings, err = InvoiceInfo(ctx, serv, req.Content, req.Model)
+	case classification.DocumentType == DocumentBankCard:
+		resp.Result, resp.Warnings, err = BankCardInfo(ctx, serv, req.Content, req.Model)
+	default:
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("no extractor for %s (country: %s)", classification.DocumentType, classification.Country))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,39 @@
+package ocr
+
+import (
+	"codeocr/lib/tool"
+	"fmt"
+)
+
+// normalizeCountries 将字段中的国家名称、国籍或代码统一转换为 ISO 3166-1 alpha-3 代码
+// 没有 ISO 代码的 ICAO 特殊值 (如无国籍 XXA) 保留 ICAO 代码, 无法识别的值清空并给出警告
+func normalizeCountries(result *DocumentResult, fields ...string) {
+	for _, field := range fields {
+		value := result.Fields[field]
+		if value == "" {
+			continue
+		}
+		country, err := tool.LookupCountry(value)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s %q is not a known country", field, value))
+			result.Fields[field] = ""
+			continue
+		}
+		result.Fields[field] = country.Code()
+	}
+}
+
+// passportCountryCodes 为 full schema 补充签发国和国籍的 alpha-2 代码以及国籍的 ICAO 代码
+// 机读区校验通过时国籍的 ICAO 代码取机读区原值, 以保留 GBN 等英国国籍类别
+func passportCountryCodes(result *DocumentResult, mrz *tool.MRZ) {
+	if country, err := tool.LookupCountry(result.Fields["country_code"]); err == nil {
+		result.Fields["country_alpha2"] = country.Alpha2
+	}
+	if country, err := tool.LookupCountry(result.Fields["nationality"]); err == nil {
+		result.Fields["nationality_alpha2"] = country.Alpha2
+		result.Fields["nationality_icao"] = country.ICAO
+	}
+	if mrz != nil && mrz.Valid() && mrz.Nationality != "" {
+		result.Fields["nationality_icao"] = mrz.Nationality
+	}
+}
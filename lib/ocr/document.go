@@ -0,0 +1,70 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"context"
+	"strings"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// PassportInfo 使用 passport 模板识别护照, 并用机读区校验视读区字段
+// opts.Extended 为 false 时只返回原有的九个字段
+func PassportInfo(ctx context.Context, serv provider.Provider, imageBase64, modelName string, opts DocumentOptions) (resp *api.OcrPassportRes, err error) {
+	result, err := recognizePassport(ctx, serv, imageBase64, modelName, opts)
+	if err != nil {
+		return nil, err
+	}
+	mrz := result.Fields["mrz"]
+	delete(result.Fields, "mrz")
+	resp = &api.OcrPassportRes{
+		Positions: result.Positions,
+		Validity:  result.Validity,
+		Warnings:  result.Warnings,
+	}
+	if err = gconv.Struct(result.Fields, &resp.PassportInfo); err != nil {
+		return nil, err
+	}
+	if opts.Extended && mrz != "" {
+		resp.PassportInfo.Mrz = strings.Split(mrz, "\n")
+	}
+	return resp, nil
+}
+
+// recognizePassport 识别护照并完成机读区校验和 finish 中的处理, 结果中保留 mrz 字段
+func recognizePassport(ctx context.Context, serv provider.Provider, imageBase64, modelName string, opts DocumentOptions) (*DocumentResult, error) {
+	opts.Language = "en"
+	result, err := recognizeDocument(ctx, serv, "passport", imageBase64, modelName, opts)
+	if err != nil {
+		return nil, err
+	}
+	normalizeCountries(result, "nationality", "country_code")
+	latinNames(result, [2]string{"surname", "surname_native"}, [2]string{"givename", "givename_native"})
+	parsedMRZ := checkPassportMRZ(result)
+	if opts.Extended {
+		passportCountryCodes(result, parsedMRZ)
+	}
+	result.finish(ctx, serv, modelName, opts)
+	return result, nil
+}
+
+// DrivingLicenseInfo 使用 driving-license 模板识别驾驶证, language 不是英文时翻译姓名、地址等字段, 原文和翻译状态在 Translations 中
+func DrivingLicenseInfo(ctx context.Context, serv provider.Provider, imageBase64, modelName string, opts DocumentOptions) (resp *api.OcrDrivingLicenseRes, err error) {
+	result, err := recognizeDocument(ctx, serv, "driving-license", imageBase64, modelName, opts)
+	if err != nil {
+		return nil, err
+	}
+	latinNames(result, [2]string{"name", "name_native"})
+	result.finish(ctx, serv, modelName, opts)
+	resp = &api.OcrDrivingLicenseRes{
+		Positions:    result.Positions,
+		Validity:     result.Validity,
+		Translations: result.Translations,
+		Warnings:     result.Warnings,
+	}
+	if err = gconv.Struct(result.Fields, &resp.DrivingLicenseInfo); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,89 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldsSchema 将简单字段列表转换为 JSON Schema
+func FieldsSchema(fields []api.ExtractField) map[string]interface{} {
+	properties := map[string]interface{}{}
+	required := []interface{}{}
+	for _, field := range fields {
+		property := map[string]interface{}{"type": field.Type}
+		if field.Type == "" {
+			property["type"] = "string"
+		}
+		if field.Description != "" {
+			property["description"] = field.Description
+		}
+		if field.Format != "" {
+			property["format"] = field.Format
+		}
+		if field.Pattern != "" {
+			property["pattern"] = field.Pattern
+		}
+		properties[field.Name] = property
+		if field.Required {
+			required = append(required, field.Name)
+		}
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+}
+
+// extractPrompt 根据 schema 生成提示词
+func extractPrompt(schema map[string]interface{}, instruction string) (string, error) {
+	schemaBytes, err := json.Marshal(schema)
+	if err != nil {
+		return "", err
+	}
+	prompt := fmt.Sprintf("Extract information from this image and return a single JSON object that conforms to this JSON Schema: %s. "+
+		"Follow each property's description, format and pattern exactly; dates with format date use yyyy-mm-dd. "+
+		"Omit properties whose values are not present in the image. Return only the JSON object.", schemaBytes)
+	if instruction = strings.TrimSpace(instruction); instruction != "" {
+		prompt += " " + instruction
+	}
+	return prompt, nil
+}
+
+// Extract 按调用方提供的 JSON Schema (或字段列表) 识别图片, 返回通过 schema 校验的对象
+func Extract(ctx context.Context, serv provider.Provider, req *api.OcrExtractReq) (result map[string]interface{}, err error) {
+	schema := req.Schema
+	if len(schema) == 0 {
+		if len(req.Fields) == 0 {
+			return nil, errors.New("either schema or fields is required")
+		}
+		schema = FieldsSchema(req.Fields)
+	}
+	if schemaType, ok := schema["type"]; ok && schemaType != "object" {
+		return nil, fmt.Errorf("schema type must be object, got %v", schemaType)
+	}
+
+	prompt, err := extractPrompt(schema, req.Instruction)
+	if err != nil {
+		return nil, err
+	}
+	content, err := completeSchema(ctx, serv, req.Content, req.Model, prompt, schema)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal([]byte(content), &result)
+	if err != nil {
+		return nil, fmt.Errorf("model output is not a json object: %w", err)
+	}
+	tool.DropNulls(result)
+	if violations := tool.ValidateSchema(result, schema); len(violations) > 0 {
+		return nil, fmt.Errorf("model output does not match schema: %s", strings.Join(violations, "; "))
+	}
+	return result, nil
+}
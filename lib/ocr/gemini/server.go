@@ -1,152 +1,133 @@
 package gemini
 
 import (
-	"bytes"
-	"codeocr/api"
+	"codeocr/lib/ocr/provider"
 	"codeocr/lib/tool"
 	"context"
-	"encoding/base64"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"regexp"
 	"strings"
 	"time"
 
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/util/gconv"
 	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
-// isHTTPLink 判断字符串是否是 HTTP/HTTPS 链接
-func isHTTPLink(s string) bool {
-	// 正则表达式匹配 HTTP/HTTPS 链接
-	pattern := `^https?://[^\s/$.?#].[^\s]*$`
-	re := regexp.MustCompile(pattern)
-	return re.MatchString(s)
-}
-
-func extractNumbers(input string) []string {
-	re := regexp.MustCompile(`\d+`)
-
-	numbers := re.FindAllString(input, -1)
-
-	return numbers
-}
-
-func base64ToBytes(base64Data string) ([]byte, error) {
-	if idx := strings.Index(base64Data, ","); idx != -1 {
-		base64Data = base64Data[idx+1:]
-	}
-
-	data, err := base64.StdEncoding.DecodeString(base64Data)
-	if err != nil {
-		return nil, fmt.Errorf("Base64 decode error: %v", err)
-	}
-
-	return data, nil
-}
-
-func base64ToReader(base64Str string) (io.Reader, error) {
+var (
+	platform     = "gemini"
+	defaultModel = "gemini-2.5-flash-lite"
+	secretKey    = "ocr.secret"
+)
 
-	if idx := strings.Index(base64Str, ","); idx != -1 {
-		base64Str = base64Str[idx+1:]
-	}
-	// 解码 Base64 字符串为字节数组
-	data, err := base64.StdEncoding.DecodeString(base64Str)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode base64: %w", err)
+// imageFormat 根据图片内容判断 genai.ImageData 需要的格式, 如 jpeg, png
+func imageFormat(imageBytes []byte) string {
+	contentType := http.DetectContentType(imageBytes)
+	if !strings.HasPrefix(contentType, "image/") {
+		return "jpeg"
 	}
-
-	// 将字节数组转换为 io.Reader
-	reader := bytes.NewReader(data)
-	return reader, nil
+	return strings.TrimPrefix(contentType, "image/")
 }
 
 type GeminiServ struct{}
 
-func (b GeminiServ) ImageNumber(ctx context.Context, imageBase64 string, modelName string) (resp string, err error) {
-
+func (b GeminiServ) Complete(ctx context.Context, req *provider.Request) (resp *provider.Response, err error) {
+	modelName := req.Model
 	if modelName == "" {
-		modelName = "gemini-2.5-flash-lite"
-	}
-	decodedBytes, err := base64ToBytes(imageBase64)
-	if err != nil {
-		return "", err
-	}
-
-	adapter, err := gcfg.NewAdapterFile("config")
-	if err != nil {
-		return "", err
-	}
-	err = adapter.AddPath("config/")
-	if err != nil {
-		return "", err
-	}
-	secret, err := adapter.Get(ctx, "ocr.secret")
-	if err != nil {
-		return "", err
+		modelName = defaultModel
 	}
 
-	client, err := genai.NewClient(ctx, option.WithAPIKey(secret.(string)))
-	if err != nil {
-		return "", err
-	}
-	defer client.Close()
-
-	// The Gemini 1.5 models are versatile and work with most use cases
-	genaiModel := client.GenerativeModel(modelName)
-
-	prompt := []genai.Part{
-		genai.ImageData("png", decodedBytes),
-		genai.Text("Get the Number from the picture"),
+	parts := make([]genai.Part, 0, len(req.Images)+1)
+	for _, image := range req.Images {
+		imageBytes, err := tool.LoadImage(ctx, image)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, genai.ImageData(imageFormat(imageBytes), imageBytes))
 	}
-	startTime := time.Now().Unix()
-	genaiResp, err := genaiModel.GenerateContent(ctx, prompt...)
-	endTime := time.Now().Unix()
+	parts = append(parts, genai.Text(req.Instruction))
 
-	if err != nil {
-		return "", err
+	var config *genai.GenerationConfig
+	if req.JSON || req.Schema != nil {
+		config = &genai.GenerationConfig{
+			ResponseMIMEType: "application/json",
+			ResponseSchema:   toGenaiSchema(req.Schema),
+		}
 	}
-
-	respBytes, _ := json.Marshal(genaiResp.Candidates[0].Content.Parts)
-	g.Log().Infof(ctx, "%s cost %d second, ocr: %s", tool.GetFuncInfo(), endTime-startTime, respBytes)
-	codes := extractNumbers(string(respBytes))
-	return codes[0], nil
+	return generateContent(ctx, modelName, config, parts...)
 }
 
-func (b GeminiServ) PassportInfo(ctx context.Context, imageBase64, modelName string) (resp *api.PassportInfo, err error) {
+// BoxFormat Gemini 以 box_2d 格式输出坐标: [y_min, x_min, y_max, x_max], 归一化到 0-1000
+func (b GeminiServ) BoxFormat(model string) provider.BoxFormat {
+	return provider.BoxFormat{Order: "yxyx", Scale: 1000}
+}
 
-	var reader io.Reader
-	var imageBytes []byte
-	if modelName == "" {
-		modelName = "gemini-1.5-flash"
+// toGenaiSchema 将 JSON Schema 转换为 Gemini 的 responseSchema, 不支持的关键字会被忽略
+func toGenaiSchema(schema map[string]interface{}) *genai.Schema {
+	if schema == nil {
+		return nil
+	}
+	genaiSchema := &genai.Schema{}
+	schemaType := schema["type"]
+	if types, ok := schemaType.([]interface{}); ok {
+		// ["string", "null"] 形式的类型转换为 Nullable
+		for _, item := range types {
+			if item == "null" {
+				genaiSchema.Nullable = true
+			} else {
+				schemaType = item
+			}
+		}
 	}
-	if isHTTPLink(imageBase64) {
-		imageResp, err := http.Get(imageBase64)
-		if err != nil {
-			return nil, err
+	switch schemaType {
+	case "string":
+		genaiSchema.Type = genai.TypeString
+	case "number":
+		genaiSchema.Type = genai.TypeNumber
+	case "integer":
+		genaiSchema.Type = genai.TypeInteger
+	case "boolean":
+		genaiSchema.Type = genai.TypeBoolean
+	case "array":
+		genaiSchema.Type = genai.TypeArray
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			genaiSchema.Items = toGenaiSchema(items)
 		}
-		defer imageResp.Body.Close()
-		reader = imageResp.Body
-		imageBytes, err = io.ReadAll(reader)
-		if err != nil {
-			return nil, err
+	default:
+		genaiSchema.Type = genai.TypeObject
+		if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			genaiSchema.Properties = map[string]*genai.Schema{}
+			for name, property := range properties {
+				if propertySchema, ok := property.(map[string]interface{}); ok {
+					genaiSchema.Properties[name] = toGenaiSchema(propertySchema)
+				}
+			}
 		}
-	} else {
-
-		reader, err = base64ToReader(imageBase64)
-		if err != nil {
-			return nil, err
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				genaiSchema.Required = append(genaiSchema.Required, fmt.Sprint(name))
+			}
 		}
-		imageBytes, err = io.ReadAll(reader)
-		if err != nil {
-			return nil, err
+	}
+	if description, ok := schema["description"].(string); ok {
+		genaiSchema.Description = description
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && genaiSchema.Type == genai.TypeString {
+		genaiSchema.Format = "enum"
+		for _, item := range enum {
+			genaiSchema.Enum = append(genaiSchema.Enum, fmt.Sprint(item))
 		}
 	}
+	return genaiSchema
+}
 
+// generateContent 调用 Gemini 并返回第一个候选结果, config 不为空时用于设置输出格式等生成参数
+// 请求被拦截、输出被过滤、截断或为空时返回 *tool.OutputError
+func generateContent(ctx context.Context, modelName string, config *genai.GenerationConfig, parts ...genai.Part) (resp *provider.Response, err error) {
 	adapter, err := gcfg.NewAdapterFile("config")
 	if err != nil {
 		return nil, err
@@ -155,161 +136,105 @@ func (b GeminiServ) PassportInfo(ctx context.Context, imageBase64, modelName str
 	if err != nil {
 		return nil, err
 	}
-	secret, err := adapter.Get(ctx, "ocr.secret")
+	secret, err := adapter.Get(ctx, secretKey)
 	if err != nil {
 		return nil, err
 	}
 
-	client, err := genai.NewClient(ctx, option.WithAPIKey(secret.(string)))
+	client, err := genai.NewClient(ctx, option.WithAPIKey(gconv.String(secret)))
 	if err != nil {
 		return nil, err
 	}
 	defer client.Close()
 
-	startTime := time.Now().Unix()
-	// The Gemini 1.5 models are versatile and work with most use cases
 	genaiModel := client.GenerativeModel(modelName)
-
-	genaiReq := []genai.Part{
-		genai.ImageData("jpeg", imageBytes),
-
-		genai.Text("用英文json格式返回出生日期(birth_date)、姓(surname, 字母大写)、名(givename, 字母大写)、护照号(passport_no)、发行日(issue_date)、过期日(expiry_date)、性别(sex, 只有F或者M)、国籍(nationality)、国家代号(country_code), 日期格式: 23/01/1994, 不需要patronymic name "),
+	if config != nil {
+		genaiModel.GenerationConfig = *config
 	}
 
-	// Generate content.
-	geminiResp, err := genaiModel.GenerateContent(ctx, genaiReq...)
-	if err != nil {
-		return nil, err
+	startTime := time.Now().Unix()
+	var genaiResp *genai.GenerateContentResponse
+	if onToken := tool.TokenHandler(ctx); onToken != nil {
+		genaiResp, err = generateContentStream(ctx, genaiModel, onToken, parts...)
+	} else {
+		genaiResp, err = genaiModel.GenerateContent(ctx, parts...)
 	}
-
 	endTime := time.Now().Unix()
-	g.Log().Infof(ctx, "%s cost %d second", tool.GetFuncInfo(), endTime-startTime)
 	if err != nil {
+		var blockedErr *genai.BlockedError
+		if errors.As(err, &blockedErr) {
+			return nil, blockedOutputError(blockedErr)
+		}
 		return nil, err
 	}
-
-	// 定义用于匹配 JSON 的正则表达式
-	re := regexp.MustCompile(`\{.*?\}`)
-
-	// 查找所有 JSON 子串
-	partsBytes, _ := json.Marshal(geminiResp.Candidates[0].Content.Parts)
-
-	jsonStrings := re.FindAllString(string(partsBytes), -1)
-	// 输出提取的 JSON 字符串
-	var passportInfo *api.PassportInfo
-	for _, jsonString := range jsonStrings {
-		jsonString = strings.ReplaceAll(jsonString, "\\n", "")
-		jsonString = strings.ReplaceAll(jsonString, "\\", "")
-
-		err := json.Unmarshal([]byte(jsonString), &passportInfo)
-		if err != nil {
-			return nil, err
-		}
-		break
+	if genaiResp == nil || len(genaiResp.Candidates) == 0 || genaiResp.Candidates[0].Content == nil {
+		return nil, &tool.OutputError{Platform: platform, Kind: tool.OutputEmpty}
 	}
-	return passportInfo, nil
-}
 
-func (b GeminiServ) DrivingLicenseInfo(ctx context.Context, imageBase64, modelName, language string) (resp *api.DriverLicenseInfo, err error) {
-	var imageBytes []byte
-	if modelName == "" {
-		modelName = "gemini-1.5-flash"
-	}
-	if isHTTPLink(imageBase64) {
-		imageResp, err := http.Get(imageBase64)
-		if err != nil {
-			return nil, err
-		}
-		defer imageResp.Body.Close()
-		imageBytes, err = io.ReadAll(imageResp.Body)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		reader, err := base64ToReader(imageBase64)
-		if err != nil {
-			return nil, err
-		}
-		imageBytes, err = io.ReadAll(reader)
-		if err != nil {
-			return nil, err
+	candidate := genaiResp.Candidates[0]
+	g.Log().Infof(ctx, "%s cost %d second, finish_reason: %s", tool.GetFuncInfo(), endTime-startTime, candidate.FinishReason)
+	var builder strings.Builder
+	for _, part := range candidate.Content.Parts {
+		if partText, ok := part.(genai.Text); ok {
+			builder.WriteString(string(partText))
 		}
 	}
-
-	adapter, err := gcfg.NewAdapterFile("config")
-	if err != nil {
-		return nil, err
+	switch candidate.FinishReason {
+	case genai.FinishReasonMaxTokens:
+		return nil, &tool.OutputError{Platform: platform, Kind: tool.OutputTruncated, FinishReason: candidate.FinishReason.String()}
+	case genai.FinishReasonSafety, genai.FinishReasonRecitation:
+		return nil, &tool.OutputError{Platform: platform, Kind: tool.OutputBlocked, FinishReason: candidate.FinishReason.String()}
 	}
-	err = adapter.AddPath("config/")
-	if err != nil {
-		return nil, err
-	}
-	secret, err := adapter.Get(ctx, "ocr.secret")
-	if err != nil {
-		return nil, err
-	}
-
-	client, err := genai.NewClient(ctx, option.WithAPIKey(secret.(string)))
-	if err != nil {
-		return nil, err
+	if strings.TrimSpace(builder.String()) == "" {
+		return nil, &tool.OutputError{Platform: platform, Kind: tool.OutputEmpty, FinishReason: candidate.FinishReason.String()}
 	}
-	defer client.Close()
-
-	genaiModel := client.GenerativeModel(modelName)
 
-	prompt := []genai.Part{
-		genai.ImageData("jpeg", imageBytes),
-		genai.Text("Extract the following fields from this driver's license image: Name, license_number, date_of_birth (format yyyy.mm.dd), issue_date (format yyyy.mm.dd), expiry_date (format yyyy.mm.dd), Address, Class, Gender. Return as JSON object."),
-	}
-	geminiResp, err := genaiModel.GenerateContent(ctx, prompt...)
-	if err != nil {
-		return nil, err
-	}
-	if len(geminiResp.Candidates) == 0 {
-		return nil, fmt.Errorf("no candidates in response")
+	resp = &provider.Response{
+		Text:         builder.String(),
+		Model:        modelName,
+		FinishReason: candidate.FinishReason.String(),
 	}
-	content := geminiResp.Candidates[0].Content.Parts[0]
-	text, ok := content.(genai.Text)
-	if !ok {
-		return nil, fmt.Errorf("unexpected content type")
-	}
-	var jsonStr string
-	startIdx := strings.Index(string(text), "{")
-	endIdx := strings.LastIndex(string(text), "}")
-	if startIdx != -1 && endIdx != -1 && endIdx > startIdx {
-		jsonStr = string(text)[startIdx : endIdx+1]
-	} else {
-		jsonStr = string(text)
-	}
-	var info api.DriverLicenseInfo
-	err = json.Unmarshal([]byte(jsonStr), &info)
-	if err != nil {
-		return nil, err
-	}
-	if language != "" && language != "English" {
-		promptStr := fmt.Sprintf("Translate to %s ONLY the following fields: Name '%s' to translated Name, Address '%s' to translated Address, Class '%s' to translated Class, Gender '%s' to translated Gender. For the other fields, COPY EXACTLY: License Number '%s', Date of Birth '%s', Issue Date '%s', Expiry Date '%s'. Return a JSON object with fields: name, license_number, date_of_birth, issue_date, expiry_date, address, class, gender using the translated or copied values.",
-			language, info.Name, info.Address, info.Class, info.Gender, info.LicenseNumber, info.DateOfBirth, info.IssueDate, info.ExpiryDate)
-		transResp, err := genaiModel.GenerateContent(ctx, genai.Text(promptStr))
-		if err != nil {
-			return &info, nil // return original on error
+	if usage := genaiResp.UsageMetadata; usage != nil {
+		resp.Usage = provider.Usage{
+			PromptTokens:     int(usage.PromptTokenCount),
+			CompletionTokens: int(usage.CandidatesTokenCount),
+			TotalTokens:      int(usage.TotalTokenCount),
 		}
-		if len(transResp.Candidates) == 0 {
-			return &info, nil
+	}
+	return resp, nil
+}
+
+// generateContentStream 以流式方式调用 Gemini, 每收到一段文本调用一次 onToken, 返回合并后的结果
+func generateContentStream(ctx context.Context, genaiModel *genai.GenerativeModel, onToken func(token string), parts ...genai.Part) (*genai.GenerateContentResponse, error) {
+	iter := genaiModel.GenerateContentStream(ctx, parts...)
+	for {
+		chunk, err := iter.Next()
+		if err == iterator.Done {
+			return iter.MergedResponse(), nil
 		}
-		transContent := transResp.Candidates[0].Content.Parts[0]
-		transText, ok := transContent.(genai.Text)
-		if !ok {
-			return &info, nil
+		if err != nil {
+			return nil, err
 		}
-		transStartIdx := strings.Index(string(transText), "{")
-		transEndIdx := strings.LastIndex(string(transText), "}")
-		if transStartIdx != -1 && transEndIdx != -1 && transEndIdx > transStartIdx {
-			transJsonStr := string(transText)[transStartIdx : transEndIdx+1]
-			err = json.Unmarshal([]byte(transJsonStr), &info)
-			if err != nil {
-				return &info, nil
+		for _, candidate := range chunk.Candidates {
+			if candidate.Content == nil {
+				continue
+			}
+			for _, part := range candidate.Content.Parts {
+				if partText, ok := part.(genai.Text); ok && partText != "" {
+					onToken(string(partText))
+				}
 			}
 		}
 	}
-	return &info, nil
+}
+
+// blockedOutputError 将 genai.BlockedError 转换为 *tool.OutputError
+func blockedOutputError(blockedErr *genai.BlockedError) *tool.OutputError {
+	outputErr := &tool.OutputError{Platform: platform, Kind: tool.OutputBlocked}
+	if blockedErr.Candidate != nil {
+		outputErr.FinishReason = blockedErr.Candidate.FinishReason.String()
+	} else if blockedErr.PromptFeedback != nil {
+		outputErr.FinishReason = blockedErr.PromptFeedback.BlockReason.String()
+	}
+	return outputErr
 }
@@ -0,0 +1,33 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"strings"
+)
+
+// IdCardInfo 识别居民身份证, side 为 front (人像面) 或 back (国徽面)
+// 人像面会按 GB 11643 校验身份证号码, 并与出生日期、性别交叉比对
+func IdCardInfo(ctx context.Context, serv provider.Provider, imageBase64, modelName, side string) (resp *api.IdCardInfo, warnings []string, err error) {
+	prompt := "Extract the following fields from the front (portrait side) of this Chinese resident ID card: name, sex (男 or 女), ethnicity, birth_date (format yyyy.mm.dd), address, id_number (18 characters). Keep Chinese text as printed. Return as JSON object."
+	if side == "back" {
+		prompt = "Extract the following fields from the back (national emblem side) of this Chinese resident ID card: issue_authority, valid_period (format yyyy.mm.dd-yyyy.mm.dd, or yyyy.mm.dd-长期). Keep Chinese text as printed. Return as JSON object."
+	}
+	var info api.IdCardInfo
+	if err = completeJSON(ctx, serv, imageBase64, modelName, prompt, &info); err != nil {
+		return nil, nil, err
+	}
+
+	info.IdNumber = strings.ToUpper(strings.ReplaceAll(info.IdNumber, " ", ""))
+	outputFormat := "2006.01.02"
+	if converted, err := tool.ParseAndFormatDate(info.BirthDate, outputFormat); err == nil {
+		info.BirthDate = converted
+	}
+	info.ValidPeriod = tool.FormatDateRange(info.ValidPeriod, outputFormat)
+	if side != "back" {
+		warnings = tool.CheckIdCard(info.IdNumber, info.BirthDate, info.Sex)
+	}
+	return &info, warnings, nil
+}
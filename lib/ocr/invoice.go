@@ -0,0 +1,66 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"fmt"
+)
+
+// InvoiceInfo 识别增值税发票或收据, 并校验明细与合计金额
+func InvoiceInfo(ctx context.Context, serv provider.Provider, imageBase64, modelName string) (resp *api.InvoiceInfo, warnings []string, err error) {
+	prompt := "Extract the following fields from this invoice (发票) or receipt: invoice_type (vat_special, vat_normal or receipt), invoice_code, invoice_number, invoice_date (format yyyy.mm.dd), buyer_name, buyer_tax_id, seller_name, seller_tax_id, items (array of objects with name, quantity, unit_price, amount, tax_rate, tax), total_amount (excluding tax), total_tax, total_with_tax. Return codes, numbers and amounts as strings exactly as printed, without currency symbols. Use an empty string for fields that are not printed. Return as JSON object."
+	var info api.InvoiceInfo
+	if err = completeJSON(ctx, serv, imageBase64, modelName, prompt, &info); err != nil {
+		return nil, nil, err
+	}
+
+	if converted, err := tool.ParseAndFormatDate(info.InvoiceDate, "2006.01.02"); err == nil {
+		info.InvoiceDate = converted
+	}
+	return &info, CheckInvoice(&info), nil
+}
+
+// CheckInvoice 校验发票明细与合计金额是否一致, 返回发现的问题
+func CheckInvoice(info *api.InvoiceInfo) (warnings []string) {
+	var sumAmount, sumTax float64
+	for i, item := range info.Items {
+		amount, err := tool.ParseAmount(item.Amount)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("item %d amount %q is not a number", i+1, item.Amount))
+			continue
+		}
+		sumAmount += amount
+
+		quantity, quantityErr := tool.ParseAmount(item.Quantity)
+		unitPrice, unitPriceErr := tool.ParseAmount(item.UnitPrice)
+		if quantityErr == nil && unitPriceErr == nil && !tool.AmountEqual(quantity*unitPrice, amount) {
+			warnings = append(warnings, fmt.Sprintf("item %d quantity %s x unit price %s does not equal amount %s", i+1, item.Quantity, item.UnitPrice, item.Amount))
+		}
+
+		// 免税等没有数值税额的明细不参与税额校验
+		tax, err := tool.ParseAmount(item.Tax)
+		if err != nil {
+			continue
+		}
+		sumTax += tax
+		if taxRate, err := tool.ParseRate(item.TaxRate); err == nil && !tool.AmountEqual(amount*taxRate, tax) {
+			warnings = append(warnings, fmt.Sprintf("item %d amount %s x tax rate %s does not equal tax %s", i+1, item.Amount, item.TaxRate, item.Tax))
+		}
+	}
+
+	totalAmount, totalAmountErr := tool.ParseAmount(info.TotalAmount)
+	totalTax, totalTaxErr := tool.ParseAmount(info.TotalTax)
+	totalWithTax, totalWithTaxErr := tool.ParseAmount(info.TotalWithTax)
+	if len(info.Items) > 0 && totalAmountErr == nil && !tool.AmountEqual(sumAmount, totalAmount) {
+		warnings = append(warnings, fmt.Sprintf("sum of item amounts %.2f does not equal total amount %s", sumAmount, info.TotalAmount))
+	}
+	if len(info.Items) > 0 && totalTaxErr == nil && !tool.AmountEqual(sumTax, totalTax) {
+		warnings = append(warnings, fmt.Sprintf("sum of item taxes %.2f does not equal total tax %s", sumTax, info.TotalTax))
+	}
+	if totalAmountErr == nil && totalTaxErr == nil && totalWithTaxErr == nil && !tool.AmountEqual(totalAmount+totalTax, totalWithTax) {
+		warnings = append(warnings, fmt.Sprintf("total amount %s plus total tax %s does not equal total with tax %s", info.TotalAmount, info.TotalTax, info.TotalWithTax))
+	}
+	return warnings
+}
@@ -0,0 +1,71 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"slices"
+	"testing"
+)
+
+func TestCheckInvoice(t *testing.T) {
+	items := func(items ...api.InvoiceItem) []api.InvoiceItem { return items }
+	tests := []struct {
+		name string
+		info api.InvoiceInfo
+		want []string
+	}{
+		{
+			name: "consistent",
+			info: api.InvoiceInfo{
+				Items: items(
+					api.InvoiceItem{Quantity: "2", UnitPrice: "50.00", Amount: "100.00", TaxRate: "13%", Tax: "13.00"},
+					api.InvoiceItem{Quantity: "1", UnitPrice: "1,000", Amount: "¥1,000.00", TaxRate: "0.06", Tax: "60.00"},
+				),
+				TotalAmount: "1100.00", TotalTax: "73.00", TotalWithTax: "1173.00",
+			},
+		},
+		{
+			name: "rounding within one cent",
+			info: api.InvoiceInfo{
+				Items:       items(api.InvoiceItem{Quantity: "3", UnitPrice: "33.333", Amount: "100.00", TaxRate: "13", Tax: "13.00"}),
+				TotalAmount: "100.00", TotalTax: "13.00", TotalWithTax: "113.00",
+			},
+		},
+		{
+			name: "tax exempt item and missing totals",
+			info: api.InvoiceInfo{
+				Items: items(api.InvoiceItem{Quantity: "", UnitPrice: "", Amount: "100.00", TaxRate: "免税", Tax: "***"}),
+			},
+		},
+		{
+			name: "item arithmetic",
+			info: api.InvoiceInfo{
+				Items:       items(api.InvoiceItem{Quantity: "2", UnitPrice: "50.00", Amount: "110.00", TaxRate: "13%", Tax: "13.00"}),
+				TotalAmount: "110.00", TotalTax: "13.00", TotalWithTax: "123.00",
+			},
+			want: []string{
+				"item 1 quantity 2 x unit price 50.00 does not equal amount 110.00",
+				"item 1 amount 110.00 x tax rate 13% does not equal tax 13.00",
+			},
+		},
+		{
+			name: "totals",
+			info: api.InvoiceInfo{
+				Items:       items(api.InvoiceItem{Amount: "100.00", Tax: "13.00"}, api.InvoiceItem{Amount: "abc"}),
+				TotalAmount: "200.00", TotalTax: "26.00", TotalWithTax: "230.00",
+			},
+			want: []string{
+				`item 2 amount "abc" is not a number`,
+				"sum of item amounts 100.00 does not equal total amount 200.00",
+				"sum of item taxes 13.00 does not equal total tax 26.00",
+				"total amount 200.00 plus total tax 26.00 does not equal total with tax 230.00",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckInvoice(&tt.info); !slices.Equal(got, tt.want) {
+				t.Errorf("CheckInvoice() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package ocr
+
+import (
+	"codeocr/lib/tool"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var mrzNamePattern = regexp.MustCompile(`[^A-Z]+`)
+
+// checkPassportMRZ 解析护照机读区并与视读区字段交叉比对
+// 校验位通过的 MRZ 值覆盖视读区的值, 文档号不做自动纠正, 不一致之处以警告说明; 姓名没有校验位, 只比对不覆盖
+// 返回解析出的机读区, 缺少或无法解析时返回 nil; 缺少、无法解析或校验位未通过时设置 result.Flags.MRZFailed
+func checkPassportMRZ(result *DocumentResult) *tool.MRZ {
+	text := result.Fields["mrz"]
+	if text == "" {
+		result.Warnings = append(result.Warnings, "mrz is missing, fields are not cross-validated")
+		result.Flags.MRZFailed = true
+		return nil
+	}
+	// 模型有时输出字面的 \n, 大写转换后变为 \N
+	mrz, err := tool.ParseMRZ(strings.NewReplacer(`\n`, "\n", `\N`, "\n").Replace(text))
+	if err != nil {
+		result.Warnings = append(result.Warnings, "mrz is not parsable: "+err.Error())
+		result.Flags.MRZFailed = true
+		return nil
+	}
+	result.Fields["mrz"] = strings.Join(mrz.Lines, "\n")
+	result.Flags.MRZFailed = !mrz.Valid()
+	for _, name := range mrz.FailedChecks() {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("mrz %s check digit failed", name))
+		// 替换形近字符得到的候选值不能覆盖视读区的值, 只作为警告给出
+		if candidate, ok := mrz.Candidates[name]; ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("mrz %s would pass its check digit as %q, not applied", name, candidate))
+		}
+	}
+
+	prefer := func(field, mrzValue string, checked bool) {
+		if mrzValue == "" || result.Fields[field] == mrzValue {
+			return
+		}
+		if !checked {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s %q differs from mrz %q", field, result.Fields[field], mrzValue))
+			return
+		}
+		if result.Fields[field] != "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s %q differs from mrz, using mrz value %q", field, result.Fields[field], mrzValue))
+		}
+		result.Fields[field] = mrzValue
+	}
+	dateValue := func(field string, date time.Time, err error) string {
+		if err != nil {
+			return ""
+		}
+		layout := "02/01/2006"
+		if templateField := result.Template.Field(field); templateField != nil && templateField.DateFormat != "" {
+			layout = templateField.DateFormat
+		}
+		return date.Format(layout)
+	}
+
+	prefer("passport_no", mrz.DocumentNumber, mrz.Checks["document_number"])
+	birth, err := mrz.Birth()
+	prefer("birth_date", dateValue("birth_date", birth, err), mrz.Checks["birth_date"])
+	expiry, err := mrz.Expiry()
+	prefer("expiry_date", dateValue("expiry_date", expiry, err), mrz.Checks["expiry_date"])
+	// 性别、签发国和国籍只受总校验位保护
+	prefer("sex", mrz.Sex, mrz.Checks["composite"] && mrz.Valid())
+	prefer("country_code", mrzCountry(mrz.IssuingState), mrz.Checks["composite"] && mrz.Valid())
+	prefer("nationality", mrzCountry(mrz.Nationality), mrz.Checks["composite"] && mrz.Valid())
+
+	// full schema 才有的字段
+	if _, ok := result.Fields["document_type"]; ok {
+		prefer("document_type", mrz.DocumentCode, mrz.Checks["composite"] && mrz.Valid())
+	}
+	if value, ok := result.Fields["personal_number"]; ok && value == "" && mrz.Checks["optional_data"] {
+		result.Fields["personal_number"] = mrz.OptionalData
+	}
+
+	// 机读区姓名只有 A-Z 且可能被截断, 比对时忽略其他字符, 只要求视读区姓名以机读区姓名开头
+	compareName := func(field, mrzValue string) {
+		viz := mrzNamePattern.ReplaceAllString(strings.ToUpper(result.Fields[field]), "")
+		mrzName := mrzNamePattern.ReplaceAllString(mrzValue, "")
+		if viz != "" && mrzName != "" && !strings.HasPrefix(viz, mrzName) {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s %q differs from mrz %q", field, result.Fields[field], mrzValue))
+		}
+	}
+	compareName("surname", mrz.Surname)
+	compareName("givename", mrz.GivenNames)
+	return mrz
+}
+
+// mrzCountry 将机读区中的国家代码转换为与视读区一致的三字母代码, 例如 D 转换为 DEU
+func mrzCountry(code string) string {
+	country, err := tool.LookupCountry(code)
+	if err != nil {
+		return code
+	}
+	return country.Code()
+}
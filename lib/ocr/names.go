@@ -0,0 +1,37 @@
+package ocr
+
+import (
+	"codeocr/lib/tool"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// latinNames 拉丁字母姓名与本国文字姓名并列返回, pairs 中每项为 {拉丁字母字段, 本国文字字段}
+// 只有本国文字姓名时按 ICAO 9303 转写补全拉丁字母姓名; 本国文字字段与拉丁字母字段相同时清空
+func latinNames(result *DocumentResult, pairs ...[2]string) {
+	for _, pair := range pairs {
+		latinField, nativeField := pair[0], pair[1]
+		native := result.Fields[nativeField]
+		if native == "" {
+			continue
+		}
+		if strings.EqualFold(native, result.Fields[latinField]) {
+			result.Fields[nativeField] = ""
+			continue
+		}
+		if result.Fields[latinField] != "" {
+			continue
+		}
+		latin, complete := tool.TransliterateICAO(native)
+		if !complete {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s has no deterministic transliteration, %s is left empty", nativeField, latinField))
+			continue
+		}
+		result.Fields[latinField] = latin
+		result.Warnings = slices.DeleteFunc(result.Warnings, func(warning string) bool {
+			return warning == latinField+" is missing"
+		})
+		result.Warnings = append(result.Warnings, fmt.Sprintf("%s is transliterated from %s according to ICAO 9303", latinField, nativeField))
+	}
+}
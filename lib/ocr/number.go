@@ -0,0 +1,176 @@
+package ocr
+
+import (
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gogf/gf/v2/frame/g"
+)
+
+// codeAttempts 验证码模式下输出不符合要求时最多请求模型的次数
+var codeAttempts = 3
+
+// CodeOptions 验证码模式的约束, 全部为零值时只返回第一段连续数字
+type CodeOptions struct {
+	Charset       string // digits, alphanumeric, letters, 默认 digits
+	CaseSensitive bool   // 为 false 时字母统一转换为大写
+	Length        int    // 精确长度, 优先于 MinLength/MaxLength
+	MinLength     int
+	MaxLength     int
+	Pattern       string // 结果需要完整匹配的正则表达式
+}
+
+var charsetPatterns = map[string]*regexp.Regexp{
+	"digits":       regexp.MustCompile(`\d+`),
+	"alphanumeric": regexp.MustCompile(`[A-Za-z0-9]+`),
+	"letters":      regexp.MustCompile(`[A-Za-z]+`),
+}
+
+var charsetDescriptions = map[string]string{
+	"digits":       "digits (0-9)",
+	"alphanumeric": "letters (A-Z) and digits (0-9)",
+	"letters":      "letters (A-Z)",
+}
+
+func (o CodeOptions) constrained() bool {
+	return o != CodeOptions{} && o != CodeOptions{Charset: "digits"}
+}
+
+func (o CodeOptions) prompt() string {
+	charset := o.Charset
+	if charset == "" {
+		charset = "digits"
+	}
+	prompt := fmt.Sprintf("Read the code in this image. It consists only of %s", charsetDescriptions[charset])
+	switch {
+	case o.Length > 0:
+		prompt += fmt.Sprintf(" and is exactly %d characters long", o.Length)
+	case o.MinLength > 0 && o.MaxLength > 0:
+		prompt += fmt.Sprintf(" and is %d to %d characters long", o.MinLength, o.MaxLength)
+	case o.MinLength > 0:
+		prompt += fmt.Sprintf(" and is at least %d characters long", o.MinLength)
+	case o.MaxLength > 0:
+		prompt += fmt.Sprintf(" and is at most %d characters long", o.MaxLength)
+	}
+	prompt += "."
+	if o.CaseSensitive && charset != "digits" {
+		prompt += " Letters are case-sensitive, keep upper and lower case exactly as printed."
+	}
+	return prompt + " Return only the code, without spaces or any other text."
+}
+
+// codeLabelPattern 模型有时在验证码前输出标签, 例如 "Code: AB12"
+var codeLabelPattern = regexp.MustCompile(`^[^:：]*[:：]`)
+
+// match 从模型输出中找出第一个满足约束的候选值
+func (o CodeOptions) match(text string, pattern *regexp.Regexp) (string, bool) {
+	charset := o.Charset
+	if charset == "" {
+		charset = "digits"
+	}
+	value := codeLabelPattern.ReplaceAllString(strings.TrimSpace(text), "")
+	candidates := charsetPatterns[charset].FindAllString(value, -1)
+	if charset != "digits" && o.Length == 0 && o.MinLength == 0 && o.MaxLength == 0 && len(candidates) > 1 {
+		// 没有长度约束时输出中的单词也能通过校验: 字母数字只考虑含有数字的片段, 较长的优先; 纯字母不拆分
+		candidates = slices.DeleteFunc(candidates, func(candidate string) bool {
+			return charset == "letters" || !strings.ContainsAny(candidate, "0123456789")
+		})
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return len(candidates[i]) > len(candidates[j])
+		})
+	}
+	// 最后把去掉标签的输出去掉空白和连字符作为候选, 处理模型按印刷格式分组输出的情况
+	candidates = append(candidates, strings.NewReplacer(" ", "", "-", "", "\n", "").Replace(strings.TrimSpace(value)))
+	for _, candidate := range candidates {
+		if !o.CaseSensitive {
+			candidate = strings.ToUpper(candidate)
+		}
+		if charsetPatterns[charset].FindString(candidate) != candidate {
+			continue
+		}
+		length := len(candidate)
+		if (o.Length > 0 && length != o.Length) || (o.MinLength > 0 && length < o.MinLength) || (o.MaxLength > 0 && length > o.MaxLength) {
+			continue
+		}
+		if pattern != nil && !pattern.MatchString(candidate) {
+			continue
+		}
+		return candidate, true
+	}
+	return "", false
+}
+
+// validate 检查约束本身是否合法, 返回编译后的 Pattern
+func (o CodeOptions) validate() (*regexp.Regexp, error) {
+	if o.Charset != "" && charsetPatterns[o.Charset] == nil {
+		return nil, fmt.Errorf("unsupported charset %q", o.Charset)
+	}
+	if o.Length < 0 || o.MinLength < 0 || o.MaxLength < 0 || (o.MaxLength > 0 && o.MinLength > o.MaxLength) {
+		return nil, errors.New("invalid code length range")
+	}
+	if o.Pattern == "" {
+		return nil, nil
+	}
+	pattern, err := regexp.Compile(`^(?:` + o.Pattern + `)$`)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return pattern, nil
+}
+
+// ImageNumber 识别图片中的数字, 返回第一段连续数字
+// opts 设置了字符集、长度或正则时按验证码模式识别, 输出不符合要求时附带上一次的结果重新请求模型, 仍不符合则返回错误
+func ImageNumber(ctx context.Context, serv provider.Provider, imageBase64, modelName string, opts CodeOptions) (resp string, err error) {
+	if !opts.constrained() {
+		text, err := complete(ctx, serv, &provider.Request{
+			Model:       modelName,
+			Images:      []string{imageBase64},
+			Instruction: "Return only the number from the image",
+		})
+		if err != nil {
+			return "", err
+		}
+		// 识别结果可能是验证码等敏感内容, 只在调试级别记录
+		g.Log().Debugf(ctx, "%s ocr: %s", tool.GetFuncInfo(), text)
+		codes := tool.ExtractNumbers(text)
+		if len(codes) == 0 {
+			return "", errors.New("no number found in model output")
+		}
+		return codes[0], nil
+	}
+
+	pattern, err := opts.validate()
+	if err != nil {
+		return "", err
+	}
+	prompt := opts.prompt()
+	var outputs []string
+	for attempt := 1; attempt <= codeAttempts; attempt++ {
+		instruction := prompt
+		if len(outputs) > 0 {
+			instruction += fmt.Sprintf(" Previous answers %q did not meet these requirements, read the image again carefully.", outputs)
+		}
+		text, err := complete(ctx, serv, &provider.Request{
+			Model:       modelName,
+			Images:      []string{imageBase64},
+			Instruction: instruction,
+		})
+		if err != nil {
+			return "", err
+		}
+		g.Log().Debugf(ctx, "%s attempt %d ocr: %s", tool.GetFuncInfo(), attempt, text)
+		if code, ok := opts.match(text, pattern); ok {
+			return code, nil
+		}
+		outputs = append(outputs, strings.TrimSpace(text))
+	}
+	// 错误会返回给调用方并写入日志, 不包含模型输出
+	return "", fmt.Errorf("code does not match the requirements after %d attempts", codeAttempts)
+}
@@ -0,0 +1,51 @@
+package ocr
+
+import "testing"
+
+func TestCodeOptionsMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CodeOptions
+		text string
+		want string
+		ok   bool
+	}{
+		{"digits with exact length", CodeOptions{Length: 4}, "12 3456", "3456", true},
+		{"digits grouped by spaces", CodeOptions{Length: 6}, "123 456", "123456", true},
+		{"digits too short", CodeOptions{Length: 6}, "1234", "", false},
+		{"label is stripped", CodeOptions{Charset: "alphanumeric", Length: 4}, "Code: ab12", "AB12", true},
+		{"case sensitive keeps case", CodeOptions{Charset: "alphanumeric", CaseSensitive: true, Length: 4}, "aB12", "aB12", true},
+		{"alphanumeric prefers tokens with digits", CodeOptions{Charset: "alphanumeric"}, "The code is X7Y9Z", "X7Y9Z", true},
+		{"letters only", CodeOptions{Charset: "letters", MinLength: 3, MaxLength: 5}, "AB CDEF", "CDEF", true},
+		{"letters reject digits", CodeOptions{Charset: "letters", Length: 4}, "AB12", "", false},
+		{"length range", CodeOptions{MinLength: 5, MaxLength: 6}, "1234 56789 123456", "56789", true},
+		{"pattern", CodeOptions{Charset: "alphanumeric", Pattern: `[A-Z]{2}\d{2}`}, "12AB AB12", "AB12", true},
+		{"pattern not matched", CodeOptions{Charset: "alphanumeric", Pattern: `\d{4}`}, "AB12", "", false},
+		{"hyphenated code", CodeOptions{Charset: "alphanumeric", Length: 8}, "AB12-CD34", "AB12CD34", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pattern, err := tt.opts.validate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, ok := tt.opts.match(tt.text, pattern); got != tt.want || ok != tt.ok {
+				t.Errorf("match(%q) = %q, %v, want %q, %v", tt.text, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestCodeOptionsValidate(t *testing.T) {
+	invalid := []CodeOptions{
+		{Charset: "hex"},
+		{Length: -1},
+		{MinLength: 6, MaxLength: 4},
+		{Pattern: "[A-"},
+	}
+	for _, opts := range invalid {
+		if _, err := opts.validate(); err == nil {
+			t.Errorf("validate(%+v) = nil, want error", opts)
+		}
+	}
+}
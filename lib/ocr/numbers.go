@@ -0,0 +1,98 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var numberValuePattern = regexp.MustCompile(`^[+-]?[\d.,]*\d[\d.,]*$`)
+
+// numbersPrompt 要求模型按平台的坐标格式列出所有数字
+func numbersPrompt(format provider.BoxFormat) string {
+	return "List every number printed in this image in reading order (top to bottom, left to right), including numbers that are part of codes, dates, amounts and meter readings. " +
+		"For each number return value (the digits exactly as printed, keeping decimal points and separators), " +
+		"label (the nearby text that describes what the number is, such as a field name or unit, or an empty string), " +
+		"box (the bounding box of the number as " + boxDescription(format) + ") " +
+		"and confidence (a number between 0 and 1 for how sure you are that the value is read correctly). " +
+		"Return a JSON object with a numbers array."
+}
+
+var numbersSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"numbers": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"value":      map[string]interface{}{"type": "string"},
+					"label":      map[string]interface{}{"type": "string"},
+					"box":        map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "number"}},
+					"confidence": map[string]interface{}{"type": "number"},
+				},
+				"required": []interface{}{"value", "label", "box", "confidence"},
+			},
+		},
+	},
+	"required": []interface{}{"numbers"},
+}
+
+// ImageNumbers 识别图片中的所有数字, 按阅读顺序返回数字、说明文字、位置和置信度
+// Content 为第一个数字, 与 number 模式保持一致
+func ImageNumbers(ctx context.Context, serv provider.Provider, imageBase64, modelName string) (resp *api.OcrRes, err error) {
+	boxFormat := provider.BoxFormatOf(serv, modelName)
+	content, err := completeSchema(ctx, serv, imageBase64, modelName, numbersPrompt(boxFormat), numbersSchema)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Numbers []struct {
+			Value      string      `json:"value"`
+			Label      string      `json:"label"`
+			Box        interface{} `json:"box"`
+			Confidence float64     `json:"confidence"`
+		} `json:"numbers"`
+	}
+	if err = decodeJSON(content, &result); err != nil {
+		return nil, err
+	}
+
+	resp = &api.OcrRes{}
+	width, height, sizeErr := imageSize(ctx, imageBase64)
+	if sizeErr != nil {
+		resp.Warnings = append(resp.Warnings, "number positions omitted: "+sizeErr.Error())
+	}
+	for _, number := range result.Numbers {
+		value := strings.Join(strings.Fields(number.Value), "")
+		if !numberValuePattern.MatchString(value) {
+			resp.Warnings = append(resp.Warnings, fmt.Sprintf("%q is not a number", number.Value))
+			continue
+		}
+		info := api.NumberInfo{
+			Value:      value,
+			Label:      strings.TrimSpace(number.Label),
+			Confidence: min(max(number.Confidence, 0), 1),
+		}
+		if sizeErr == nil {
+			if info.Pos, err = boxPolygon(number.Box, boxFormat, width, height); err != nil {
+				resp.Warnings = append(resp.Warnings, fmt.Sprintf("%s position %v: %s", value, number.Box, err.Error()))
+			}
+		}
+		resp.Numbers = append(resp.Numbers, info)
+	}
+	if len(resp.Numbers) == 0 {
+		return nil, errors.New("no number found in model output")
+	}
+	// 模型输出的顺序不一定是阅读顺序, 所有数字都有位置时按位置排序
+	if !slices.ContainsFunc(resp.Numbers, func(info api.NumberInfo) bool { return len(info.Pos) == 0 }) {
+		sortReadingOrder(resp.Numbers, func(info api.NumberInfo) []api.Point { return info.Pos })
+	}
+	resp.Content = resp.Numbers[0].Value
+	return resp, nil
+}
@@ -0,0 +1,179 @@
+package openai
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcfg"
+)
+
+// Client 调用 OpenAI 兼容的 chat/completions 接口, 各平台包只提供配置
+type Client struct {
+	Platform     string // 平台名称, 用于错误信息
+	EndPoint     string
+	SecretKey    string // 配置文件中密钥的键
+	DefaultModel string
+	// 结构化输出方式: json_schema, json_object, 为空表示只通过提示词约束
+	ResponseFormatType string
+	// ModelBoxFormat 返回模型输出坐标的格式, 为 nil 时使用 provider.DefaultBoxFormat
+	ModelBoxFormat func(model string) provider.BoxFormat
+}
+
+func (c *Client) Complete(ctx context.Context, req *provider.Request) (resp *provider.Response, err error) {
+	modelName := req.Model
+	if modelName == "" {
+		modelName = c.DefaultModel
+	}
+	reqBody, err := json.Marshal(c.chatRequest(modelName, req))
+	if err != nil {
+		return nil, err
+	}
+
+	chatResp, err := c.chatCompletion(ctx, string(reqBody))
+	if err != nil {
+		return nil, err
+	}
+	choice := chatResp.Choices[0]
+	resp = &provider.Response{
+		Text:         choice.Message.Content,
+		Model:        modelName,
+		FinishReason: choice.FinishReason,
+		Usage: provider.Usage{
+			PromptTokens:     chatResp.Usage.PromptTokens,
+			CompletionTokens: chatResp.Usage.CompletionTokens,
+			TotalTokens:      chatResp.Usage.TotalTokens,
+		},
+	}
+	return resp, nil
+}
+
+// BoxFormat 实现 provider.Grounder
+func (c *Client) BoxFormat(model string) provider.BoxFormat {
+	if model == "" {
+		model = c.DefaultModel
+	}
+	if c.ModelBoxFormat == nil {
+		return provider.DefaultBoxFormat
+	}
+	return c.ModelBoxFormat(model)
+}
+
+// chatRequest 将通用请求转换为 chat/completions 请求体
+func (c *Client) chatRequest(modelName string, req *provider.Request) api.BigModelReq {
+	content := make([]api.BigModelReqContent, 0, len(req.Images)+1)
+	for _, image := range req.Images {
+		content = append(content, api.BigModelReqContent{Type: "image_url", ImageUrl: &api.BigModelReqImageUrl{Url: image}})
+	}
+	content = append(content, api.BigModelReqContent{Type: "text", Text: req.Instruction})
+
+	chatReq := api.BigModelReq{
+		Model: modelName,
+		Messages: []api.BigModelReqMessage{
+			{Role: "user", Content: content},
+		},
+	}
+	if !req.JSON && req.Schema == nil {
+		return chatReq
+	}
+	switch {
+	case c.ResponseFormatType == "json_schema" && req.Schema != nil:
+		chatReq.ResponseFormat = &api.BigModelResponseFormat{Type: c.ResponseFormatType, JsonSchema: &api.BigModelJsonSchema{Name: "result", Schema: req.Schema}}
+	case c.ResponseFormatType != "":
+		chatReq.ResponseFormat = &api.BigModelResponseFormat{Type: "json_object"}
+	}
+	return chatReq
+}
+
+// chatCompletion 调用 chat/completions 接口, 返回只包含第一个 choice 的响应
+// ctx 中注册了 tool.TokenHandler 时以 stream 模式请求并逐段回调
+// 输出被拦截、过滤、截断或为空时返回 *tool.OutputError
+func (c *Client) chatCompletion(ctx context.Context, reqBody string) (resp *api.BigModelResp, err error) {
+	adapter, err := gcfg.NewAdapterFile("config")
+	if err != nil {
+		return nil, err
+	}
+	err = adapter.AddPath("config/")
+	if err != nil {
+		return nil, err
+	}
+	secret, err := adapter.Get(ctx, c.SecretKey)
+	if err != nil {
+		return nil, err
+	}
+
+	onToken := tool.TokenHandler(ctx)
+	if onToken != nil {
+		reqBody, err = tool.EnableStream(reqBody)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	payload := strings.NewReader(reqBody)
+	client := &http.Client{}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.EndPoint, payload)
+	if err != nil {
+		g.Log().Errorf(ctx, "http_error: %s", err.Error())
+		return nil, err
+	}
+	httpReq.Header.Add("Authorization", fmt.Sprintf("Bearer %s", secret))
+	httpReq.Header.Add("Content-Type", "application/json")
+
+	startTime := time.Now().Unix()
+	httpResp, err := client.Do(httpReq)
+	if err != nil {
+		g.Log().Errorf(ctx, "http_request: %s", err.Error())
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(httpResp.Body)
+		g.Log().Warningf(ctx, "%s status %d, resp: %s", tool.GetFuncInfo(), httpResp.StatusCode, body)
+		return nil, fmt.Errorf("%s: http status %d", c.Platform, httpResp.StatusCode)
+	}
+
+	var chatResp *api.BigModelResp
+	if onToken != nil {
+		var choice api.BigModelChoices
+		choice.Message.Content, choice.FinishReason, err = tool.ReadChatStream(httpResp.Body, onToken)
+		if err != nil {
+			g.Log().Errorf(ctx, "read_stream: %s", err.Error())
+			return nil, err
+		}
+		chatResp = &api.BigModelResp{Choices: []api.BigModelChoices{choice}}
+	} else {
+		body, err := io.ReadAll(httpResp.Body)
+		if err != nil {
+			g.Log().Errorf(ctx, "io_ReadAll: %s", err.Error())
+			return nil, err
+		}
+		err = json.Unmarshal(body, &chatResp)
+		if err != nil {
+			return nil, err
+		}
+		if chatResp == nil || len(chatResp.Choices) == 0 {
+			g.Log().Warningf(ctx, "%s resp: %s", tool.GetFuncInfo(), body)
+			return nil, &tool.OutputError{Platform: c.Platform, Kind: tool.OutputEmpty}
+		}
+		chatResp.Choices = chatResp.Choices[:1]
+	}
+	endTime := time.Now().Unix()
+	choice := chatResp.Choices[0]
+	g.Log().Infof(ctx, "%s cost %d second, finish_reason: %s", tool.GetFuncInfo(), endTime-startTime, choice.FinishReason)
+	if err = tool.CheckFinishReason(c.Platform, choice.FinishReason, choice.Message.Content); err != nil {
+		// 不输出内容本身, 避免卡号等敏感信息进入日志
+		g.Log().Warningf(ctx, "%s finish_reason: %s, content length: %d", tool.GetFuncInfo(), choice.FinishReason, len(choice.Message.Content))
+		return nil, err
+	}
+	return chatResp, nil
+}
@@ -0,0 +1,54 @@
+package openai
+
+import (
+	"codeocr/lib/ocr/provider"
+	"testing"
+)
+
+func TestChatRequest(t *testing.T) {
+	schema := map[string]interface{}{"type": "object"}
+	tests := []struct {
+		name       string
+		formatType string
+		req        provider.Request
+		want       string // 为空表示不设置 response_format
+		withSchema bool
+	}{
+		{"plain text", "json_schema", provider.Request{Instruction: "read"}, "", false},
+		{"json_schema with schema", "json_schema", provider.Request{Schema: schema}, "json_schema", true},
+		{"json_schema without schema", "json_schema", provider.Request{JSON: true}, "json_object", false},
+		{"json_object ignores schema", "json_object", provider.Request{Schema: schema}, "json_object", false},
+		{"prompt only", "", provider.Request{JSON: true, Schema: schema}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{ResponseFormatType: tt.formatType}
+			req := tt.req
+			req.Images = []string{"data:image/png;base64,AAAA"}
+			chatReq := client.chatRequest("model", &req)
+			if content := chatReq.Messages[0].Content; len(content) != 2 || content[0].Type != "image_url" || content[1].Type != "text" {
+				t.Errorf("chatRequest() content = %+v, want an image and the instruction", content)
+			}
+			format := ""
+			if chatReq.ResponseFormat != nil {
+				format = chatReq.ResponseFormat.Type
+			}
+			if format != tt.want || (chatReq.ResponseFormat != nil && chatReq.ResponseFormat.JsonSchema != nil) != tt.withSchema {
+				t.Errorf("chatRequest() response_format = %+v, want %q schema %v", chatReq.ResponseFormat, tt.want, tt.withSchema)
+			}
+		})
+	}
+}
+
+func TestBoxFormat(t *testing.T) {
+	qwen := &Client{DefaultModel: "Qwen/Qwen2.5-VL-7B-Instruct", ModelBoxFormat: provider.QwenBoxFormat}
+	if got := qwen.BoxFormat(""); got != (provider.BoxFormat{Order: "xyxy", Factor: provider.QwenFactor}) {
+		t.Errorf("BoxFormat() of the default qwen2.5-vl model = %+v", got)
+	}
+	if got := qwen.BoxFormat("qwen-vl-max"); got != provider.DefaultBoxFormat {
+		t.Errorf("BoxFormat(qwen-vl-max) = %+v, want the default format", got)
+	}
+	if got := (&Client{}).BoxFormat("glm-4v-flash"); got != provider.DefaultBoxFormat {
+		t.Errorf("BoxFormat() without ModelBoxFormat = %+v, want the default format", got)
+	}
+}
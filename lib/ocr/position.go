@@ -0,0 +1,146 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// boxDescription 描述平台模型输出坐标框的格式, 用于提示词
+func boxDescription(format provider.BoxFormat) string {
+	order := "[x_min, y_min, x_max, y_max]"
+	if format.Order == "yxyx" {
+		order = "[y_min, x_min, y_max, x_max]"
+	}
+	if format.Scale > 0 {
+		return fmt.Sprintf("%s normalized to 0-%d", order, format.Scale)
+	}
+	return order + " in image pixels"
+}
+
+// positionsPrompt 要求模型按平台的坐标格式输出每个字段的位置
+func positionsPrompt(format provider.BoxFormat) string {
+	return fmt.Sprintf(" Also return a positions object that maps every field name to the bounding box of its value in the image as %s. Omit fields that are not present.", boxDescription(format))
+}
+
+// positionsSchema 在模板 schema 中加入 positions 属性
+func positionsSchema(schema map[string]interface{}, fields []TemplateField) map[string]interface{} {
+	box := map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "number"}}
+	properties := map[string]interface{}{}
+	for _, field := range fields {
+		properties[field.Name] = box
+	}
+	schema["properties"].(map[string]interface{})["positions"] = map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	return schema
+}
+
+// fieldPositions 将模型输出的坐标框转换为原图像素坐标下的四边形, 顺序为左上、右上、右下、左下
+func fieldPositions(ctx context.Context, imageBase64 string, format provider.BoxFormat, boxes map[string]interface{}, fields map[string]string) (positions []api.KeyValueInfo, warnings []string) {
+	if len(boxes) == 0 {
+		return nil, []string{"model returned no field positions"}
+	}
+	width, height, err := imageSize(ctx, imageBase64)
+	if err != nil {
+		return nil, []string{"field positions omitted: " + err.Error()}
+	}
+	for name, value := range fields {
+		box, ok := boxes[name]
+		if !ok || value == "" {
+			continue
+		}
+		polygon, err := boxPolygon(box, format, width, height)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s position %v: %s", name, box, err.Error()))
+			continue
+		}
+		positions = append(positions, api.KeyValueInfo{Key: name, Value: value, Pos: polygon})
+	}
+	sortPositions(positions)
+	return positions, warnings
+}
+
+// imageSize 读取原图的宽高, 用于将模型输出的坐标换算为原图像素
+func imageSize(ctx context.Context, imageBase64 string) (width, height int, err error) {
+	imageBytes, err := tool.LoadImage(ctx, imageBase64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return tool.ImageSize(imageBytes)
+}
+
+func boxPolygon(box interface{}, format provider.BoxFormat, width, height int) ([]api.Point, error) {
+	values := gconv.Float64s(box)
+	// 部分模型把坐标框包在一层数组中输出, 例如 [[x1, y1, x2, y2]]
+	if nested, ok := box.([]interface{}); ok && len(nested) == 1 {
+		values = gconv.Float64s(nested[0])
+	}
+	if len(values) != 4 {
+		return nil, fmt.Errorf("expect 4 coordinates, got %d", len(values))
+	}
+	x1, y1, x2, y2 := values[0], values[1], values[2], values[3]
+	if format.Order == "yxyx" {
+		x1, y1, x2, y2 = values[1], values[0], values[3], values[2]
+	}
+	if format.Scale > 0 {
+		scale := float64(format.Scale)
+		x1, x2 = x1*float64(width)/scale, x2*float64(width)/scale
+		y1, y2 = y1*float64(height)/scale, y2*float64(height)/scale
+	} else if inputWidth, inputHeight := format.InputSize(width, height); inputWidth != width || inputHeight != height {
+		// 坐标是缩放后输入图片的像素, 按缩放比例换算回原图
+		scaleX, scaleY := float64(width)/float64(inputWidth), float64(height)/float64(inputHeight)
+		x1, x2 = x1*scaleX, x2*scaleX
+		y1, y2 = y1*scaleY, y2*scaleY
+	}
+	left, right := clamp(math.Min(x1, x2), width), clamp(math.Max(x1, x2), width)
+	top, bottom := clamp(math.Min(y1, y2), height), clamp(math.Max(y1, y2), height)
+	if left == right || top == bottom {
+		return nil, errors.New("empty box")
+	}
+	return []api.Point{{X: left, Y: top}, {X: right, Y: top}, {X: right, Y: bottom}, {X: left, Y: bottom}}, nil
+}
+
+func clamp(value float64, limit int) int {
+	return min(max(int(math.Round(value)), 0), limit)
+}
+
+// sortPositions 按阅读顺序 (从上到下, 从左到右) 排列字段位置, 位置相同时按字段名排列
+func sortPositions(positions []api.KeyValueInfo) {
+	sort.Slice(positions, func(i, j int) bool { return positions[i].Key < positions[j].Key })
+	sortReadingOrder(positions, func(info api.KeyValueInfo) []api.Point { return info.Pos })
+}
+
+// sortReadingOrder 按阅读顺序稳定排列 items: 纵向重叠超过较矮框一半高度的框归为同一行, 行按上边从上到下, 行内按左边从左到右
+func sortReadingOrder[T any](items []T, pos func(T) []api.Point) {
+	sorted := slices.Clone(items)
+	sort.SliceStable(sorted, func(i, j int) bool { return pos(sorted[i])[0].Y < pos(sorted[j])[0].Y })
+	var lines [][]T
+	for _, item := range sorted {
+		if n := len(lines); n > 0 && slices.ContainsFunc(lines[n-1], func(other T) bool { return sameLine(pos(item), pos(other)) }) {
+			lines[n-1] = append(lines[n-1], item)
+			continue
+		}
+		lines = append(lines, []T{item})
+	}
+	items = items[:0]
+	for _, line := range lines {
+		sort.SliceStable(line, func(i, j int) bool { return pos(line[i])[0].X < pos(line[j])[0].X })
+		items = append(items, line...)
+	}
+}
+
+// sameLine 判断两个框的纵向重叠是否超过较矮框高度的一半
+func sameLine(a, b []api.Point) bool {
+	overlap := min(a[2].Y, b[2].Y) - max(a[0].Y, b[0].Y)
+	return overlap*2 > min(a[2].Y-a[0].Y, b[2].Y-b[0].Y)
+}
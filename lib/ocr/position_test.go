@@ -0,0 +1,67 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"slices"
+	"testing"
+)
+
+func positionBox(key string, left, top, right, bottom int) api.KeyValueInfo {
+	return api.KeyValueInfo{Key: key, Pos: []api.Point{{X: left, Y: top}, {X: right, Y: top}, {X: right, Y: bottom}, {X: left, Y: bottom}}}
+}
+
+func TestSortPositions(t *testing.T) {
+	tests := []struct {
+		name      string
+		positions []api.KeyValueInfo
+		want      []string
+	}{
+		{
+			name: "slightly offset boxes on the same line",
+			positions: []api.KeyValueInfo{
+				positionBox("name", 10, 100, 200, 130),
+				positionBox("sex", 300, 96, 360, 126),
+				positionBox("label", 220, 103, 280, 133),
+			},
+			want: []string{"name", "label", "sex"},
+		},
+		{
+			name: "separate lines from top to bottom",
+			positions: []api.KeyValueInfo{
+				positionBox("address", 10, 200, 400, 230),
+				positionBox("sex", 300, 100, 360, 130),
+				positionBox("name", 10, 104, 200, 134),
+				positionBox("number", 10, 300, 400, 330),
+			},
+			want: []string{"name", "sex", "address", "number"},
+		},
+		{
+			name: "small overlap starts a new line",
+			positions: []api.KeyValueInfo{
+				positionBox("second", 10, 120, 200, 150),
+				positionBox("first", 300, 100, 360, 130),
+			},
+			want: []string{"first", "second"},
+		},
+		{
+			name: "same position ordered by key",
+			positions: []api.KeyValueInfo{
+				positionBox("b", 10, 100, 200, 130),
+				positionBox("a", 10, 100, 200, 130),
+			},
+			want: []string{"a", "b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortPositions(tt.positions)
+			var keys []string
+			for _, position := range tt.positions {
+				keys = append(keys, position.Key)
+			}
+			if !slices.Equal(keys, tt.want) {
+				t.Errorf("sortPositions() = %q, want %q", keys, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// profileDateLayouts OutputProfile.DateFormat 对应的 Go layout
+var profileDateLayouts = map[string]string{
+	"iso":        "2006-01-02",
+	"dd/mm/yyyy": "02/01/2006",
+	"mm/dd/yyyy": "01/02/2006",
+	"yyyy.mm.dd": "2006.01.02",
+	"yyyymmdd":   "20060102",
+}
+
+// sexCodes 各种写法的性别统一为 M, F 或 X
+var sexCodes = map[string]string{
+	"M": "M", "MALE": "M", "MAN": "M", "男": "M", "1": "M",
+	"F": "F", "FEMALE": "F", "WOMAN": "F", "女": "F", "2": "F",
+	"X": "X", "<": "X", "UNSPECIFIED": "X", "9": "X",
+}
+
+var sexFormats = map[string]map[string]string{
+	"letter":  {"M": "M", "F": "F", "X": "X", "": ""},
+	"word":    {"M": "male", "F": "female", "X": "unspecified", "": ""},
+	"iso5218": {"M": "1", "F": "2", "X": "9", "": "0"},
+}
+
+// ApplyProfile 按请求的输出格式转换日期、姓名和性别字段, 字段语义由模板的 type 决定
+// 无法按模板日期格式解析的值保持不变, 字段位置中的值同步更新
+func (r *DocumentResult) ApplyProfile(profile api.OutputProfile) {
+	if r.Template == nil {
+		return
+	}
+	for _, field := range r.Template.Fields {
+		value, ok := r.Fields[field.Name]
+		if !ok {
+			continue
+		}
+		switch field.Type {
+		case "date":
+			layout, ok := profileDateLayouts[profile.DateFormat]
+			if !ok || value == "" {
+				continue
+			}
+			if date, err := time.Parse(field.DateFormat, value); err == nil {
+				value = date.Format(layout)
+			}
+		case "name":
+			switch profile.NameCase {
+			case "upper":
+				value = strings.ToUpper(value)
+			case "lower":
+				value = strings.ToLower(value)
+			case "title":
+				value = titleCase(value)
+			}
+		case "sex":
+			if encoding, ok := sexFormats[profile.SexFormat]; ok {
+				code, known := sexCodes[strings.ToUpper(strings.TrimSpace(value))]
+				if known || value == "" {
+					value = encoding[code]
+				}
+			}
+		}
+		r.Fields[field.Name] = value
+	}
+	for i := range r.Positions {
+		if value, ok := r.Fields[r.Positions[i].Key]; ok {
+			r.Positions[i].Value = value
+		}
+	}
+}
+
+// titleCase 每个单词首字母大写, 连字符和撇号之后也视为单词开头, 例如 JEAN-PIERRE O'NEIL 转换为 Jean-Pierre O'Neil
+func titleCase(value string) string {
+	runes := []rune(strings.ToLower(value))
+	start := true
+	for i, r := range runes {
+		if start {
+			runes[i] = unicode.ToUpper(r)
+		}
+		start = r == ' ' || r == '-' || r == '\''
+	}
+	return string(runes)
+}
@@ -0,0 +1,87 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"maps"
+	"testing"
+)
+
+func TestApplyProfile(t *testing.T) {
+	template := &DocumentTemplate{Fields: []TemplateField{
+		{Name: "surname", Type: "name"},
+		{Name: "birth_date", Type: "date", DateFormat: "02/01/2006"},
+		{Name: "sex", Type: "sex"},
+		{Name: "passport_no"},
+	}}
+	fields := map[string]string{"surname": "O'NEIL-SMITH", "birth_date": "08/06/1996", "sex": "女", "passport_no": "e1234567"}
+	tests := []struct {
+		name    string
+		profile api.OutputProfile
+		fields  map[string]string
+		want    map[string]string
+	}{
+		{
+			name: "empty profile keeps values",
+			want: fields,
+		},
+		{
+			name:    "iso date, title case and word sex",
+			profile: api.OutputProfile{DateFormat: "iso", NameCase: "title", SexFormat: "word"},
+			want:    map[string]string{"surname": "O'Neil-Smith", "birth_date": "1996-06-08", "sex": "female", "passport_no": "e1234567"},
+		},
+		{
+			name:    "us date, lower case and iso5218 sex",
+			profile: api.OutputProfile{DateFormat: "mm/dd/yyyy", NameCase: "lower", SexFormat: "iso5218"},
+			want:    map[string]string{"surname": "o'neil-smith", "birth_date": "06/08/1996", "sex": "2", "passport_no": "e1234567"},
+		},
+		{
+			name:    "unparsable date and unknown sex are kept",
+			profile: api.OutputProfile{DateFormat: "yyyymmdd", NameCase: "upper", SexFormat: "letter"},
+			fields:  map[string]string{"surname": "Smith", "birth_date": "1996", "sex": "?"},
+			want:    map[string]string{"surname": "SMITH", "birth_date": "1996", "sex": "?"},
+		},
+		{
+			name:    "empty sex in iso5218 is not known",
+			profile: api.OutputProfile{SexFormat: "iso5218"},
+			fields:  map[string]string{"sex": "", "birth_date": ""},
+			want:    map[string]string{"sex": "0", "birth_date": ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.fields
+			if input == nil {
+				input = fields
+			}
+			result := &DocumentResult{Template: template, Fields: maps.Clone(input)}
+			for key, value := range result.Fields {
+				result.Positions = append(result.Positions, api.KeyValueInfo{Key: key, Value: value})
+			}
+			result.ApplyProfile(tt.profile)
+			if !maps.Equal(result.Fields, tt.want) {
+				t.Errorf("ApplyProfile() fields = %v, want %v", result.Fields, tt.want)
+			}
+			for _, position := range result.Positions {
+				if position.Value != tt.want[position.Key] {
+					t.Errorf("ApplyProfile() position %s = %q, want %q", position.Key, position.Value, tt.want[position.Key])
+				}
+			}
+		})
+	}
+}
+
+func TestTitleCase(t *testing.T) {
+	tests := []struct {
+		value, want string
+	}{
+		{"JEAN-PIERRE O'NEIL", "Jean-Pierre O'Neil"},
+		{"anna maria", "Anna Maria"},
+		{"ÉLODIE MÜLLER", "Élodie Müller"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := titleCase(tt.value); got != tt.want {
+			t.Errorf("titleCase(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
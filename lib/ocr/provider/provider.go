@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"math"
+	"strings"
+)
+
+// Provider 各平台实现的基础能力: 发送图片和指令, 返回模型输出的文本或 JSON
+// 证件识别等业务逻辑在 lib/ocr 中基于该接口实现
+type Provider interface {
+	Complete(ctx context.Context, req *Request) (resp *Response, err error)
+}
+
+// Request 一次模型调用
+type Request struct {
+	Model       string                 // 为空时使用平台默认模型
+	Images      []string               // base64 (可带 data URL 前缀) 或 HTTP 链接, 可以为空
+	Instruction string                 // 文本指令
+	JSON        bool                   // 要求输出 JSON 对象
+	Schema      map[string]interface{} // 输出需要符合的 JSON Schema, 平台支持时使用原生结构化输出
+}
+
+// Response 模型输出
+type Response struct {
+	Text         string `json:"text"`
+	Model        string `json:"model"`
+	FinishReason string `json:"finish_reason"`
+	Usage        Usage  `json:"usage"`
+}
+
+// Usage token 用量
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// BoxFormat 模型输出坐标框的格式
+type BoxFormat struct {
+	Order  string // xyxy: [x_min, y_min, x_max, y_max], yxyx: [y_min, x_min, y_max, x_max]
+	Scale  int    // 坐标归一化到 0-Scale, 为 0 表示坐标是图片像素
+	Factor int    // 坐标是图片像素时, 模型看到的图片宽高被缩放为 Factor 的整数倍, 为 0 表示坐标是原图像素
+}
+
+// Qwen2.5-VL 默认的图片预处理参数, 宽高取 28 的整数倍, 像素数限制在 4*28*28 到 16384*28*28 之间
+const (
+	QwenFactor    = 28
+	qwenMinPixels = 4 * 28 * 28
+	qwenMaxPixels = 16384 * 28 * 28
+)
+
+// InputSize 返回原图缩放后输入模型的宽高, 与 Qwen2.5-VL 的 smart_resize 一致; Factor 为 0 时返回原图宽高
+func (f BoxFormat) InputSize(width, height int) (int, int) {
+	if f.Factor <= 0 || width <= 0 || height <= 0 {
+		return width, height
+	}
+	factor, w, h := float64(f.Factor), float64(width), float64(height)
+	resizedWidth := max(factor, math.RoundToEven(w/factor)*factor)
+	resizedHeight := max(factor, math.RoundToEven(h/factor)*factor)
+	if resizedWidth*resizedHeight > qwenMaxPixels {
+		beta := math.Sqrt(w * h / qwenMaxPixels)
+		resizedWidth = max(factor, math.Floor(w/beta/factor)*factor)
+		resizedHeight = max(factor, math.Floor(h/beta/factor)*factor)
+	} else if resizedWidth*resizedHeight < qwenMinPixels {
+		beta := math.Sqrt(qwenMinPixels / (w * h))
+		resizedWidth = math.Ceil(w*beta/factor) * factor
+		resizedHeight = math.Ceil(h*beta/factor) * factor
+	}
+	return int(resizedWidth), int(resizedHeight)
+}
+
+// QwenBoxFormat Qwen2.5-VL 输出缩放后输入图片的像素坐标, 更早的 Qwen-VL 模型输出归一化到 0-1000 的坐标
+func QwenBoxFormat(model string) BoxFormat {
+	if strings.Contains(strings.ToLower(model), "qwen2.5-vl") {
+		return BoxFormat{Order: "xyxy", Factor: QwenFactor}
+	}
+	return DefaultBoxFormat
+}
+
+// DefaultBoxFormat 未实现 Grounder 的平台使用的坐标格式
+var DefaultBoxFormat = BoxFormat{Order: "xyxy", Scale: 1000}
+
+// Grounder 由能够输出字段坐标的平台实现, 说明指定模型输出坐标的格式
+type Grounder interface {
+	BoxFormat(model string) BoxFormat
+}
+
+// BoxFormatOf 返回平台模型输出坐标的格式
+func BoxFormatOf(p Provider, model string) BoxFormat {
+	if grounder, ok := p.(Grounder); ok {
+		return grounder.BoxFormat(model)
+	}
+	return DefaultBoxFormat
+}
@@ -1,38 +1,96 @@
 package ocr
 
 import (
-	"codeocr/api"
 	"codeocr/lib/ocr/bigmodel"
 	"codeocr/lib/ocr/gemini"
 	"codeocr/lib/ocr/mistral"
 	"codeocr/lib/ocr/modelscope"
 	"codeocr/lib/ocr/openrouter"
+	"codeocr/lib/ocr/provider"
 	"codeocr/lib/ocr/siliconflow"
+	"codeocr/lib/tool"
 	"context"
+	"fmt"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
 )
 
 var (
 	defaultPlatform = "gemini"
-	platformMap     = map[string]OcrServer{
+	platformMap     = map[string]provider.Provider{
 		"gemini":      gemini.GeminiServ{},
-		"bigmodel":    bigmodel.BigModelServ{},
-		"mistral":     mistral.MistralServ{},
-		"openrouter":  openrouter.OpenRouterServ{},
-		"siliconflow": siliconflow.SiliconflowServ{},
-		"modelscope":  modelscope.ModelscopeServ{},
+		"bigmodel":    bigmodel.New(),
+		"mistral":     mistral.New(),
+		"openrouter":  openrouter.New(),
+		"siliconflow": siliconflow.New(),
+		"modelscope":  modelscope.New(),
 	}
 )
 
-type OcrServer interface {
-	ImageNumber(ctx context.Context, imageBase64, modelName string) (resp string, err error)
-	PassportInfo(ctx context.Context, imageBase64, modelName string) (resp *api.PassportInfo, err error)
-	DrivingLicenseInfo(ctx context.Context, imageBase64, modelName, language string) (resp *api.DriverLicenseInfo, err error)
-}
-
-func NewOcr(platform string) (serv OcrServer) {
+func NewOcr(platform string) (serv provider.Provider) {
 	if serv, ok := platformMap[platform]; !ok {
 		return platformMap[defaultPlatform]
 	} else {
 		return serv
 	}
 }
+
+// platformOf 返回 serv 在 platformMap 中的平台名称, 不在其中时返回类型名
+func platformOf(serv provider.Provider) string {
+	for name, platformServ := range platformMap {
+		if platformServ == serv {
+			return name
+		}
+	}
+	return fmt.Sprintf("%T", serv)
+}
+
+// complete 发送一张图片和指令, 返回模型输出的文本
+func complete(ctx context.Context, serv provider.Provider, req *provider.Request) (text string, err error) {
+	resp, err := serv.Complete(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	g.Log().Infof(ctx, "%s model: %s, usage: %+v", tool.GetFuncInfo(), resp.Model, resp.Usage)
+	return resp.Text, nil
+}
+
+// completeJSON 发送一张图片和指令, 将模型输出的 JSON 对象解析到 pointer
+// 模型可能把值输出为数字或字符串, 通过 gjson 解析后按 pointer 的字段类型转换
+func completeJSON(ctx context.Context, serv provider.Provider, imageBase64, modelName, instruction string, pointer interface{}) error {
+	text, err := complete(ctx, serv, &provider.Request{
+		Model:       modelName,
+		Images:      []string{imageBase64},
+		Instruction: instruction,
+		JSON:        true,
+	})
+	if err != nil {
+		return err
+	}
+	return decodeJSON(tool.ExtractJSON(text), pointer)
+}
+
+// decodeJSON 将模型输出的 JSON 对象解析到 pointer
+func decodeJSON(content string, pointer interface{}) error {
+	valuesJson, err := gjson.DecodeToJson(content)
+	if err != nil {
+		return fmt.Errorf("model output is not a json object: %w", err)
+	}
+	return valuesJson.Scan(pointer)
+}
+
+// completeSchema 按 JSON Schema 约束模型输出, 返回模型输出中的 JSON 对象文本
+func completeSchema(ctx context.Context, serv provider.Provider, imageBase64, modelName, instruction string, schema map[string]interface{}) (string, error) {
+	text, err := complete(ctx, serv, &provider.Request{
+		Model:       modelName,
+		Images:      []string{imageBase64},
+		Instruction: instruction,
+		JSON:        true,
+		Schema:      schema,
+	})
+	if err != nil {
+		return "", err
+	}
+	return tool.ExtractJSON(text), nil
+}
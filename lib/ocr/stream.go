@@ -0,0 +1,135 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/tool"
+	"context"
+	"errors"
+	"regexp"
+)
+
+// 流式识别过程中推送的事件
+const (
+	EventStarted = "started" // 开始请求上游模型
+	EventToken   = "token"   // 模型输出的一段文本
+	EventField   = "field"   // 从已输出文本中解析出的字段, 只有护照流式识别推送
+	EventResult  = "result"  // 校验后的最终结果
+	EventError   = "error"   // 识别失败
+)
+
+// EmitFunc 接收流式识别事件
+type EmitFunc func(event string, data interface{})
+
+// StreamStarted started 事件的数据
+type StreamStarted struct {
+	Platform string `json:"platform"`
+	Model    string `json:"model"`
+}
+
+// StreamField field 事件的数据
+type StreamField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// StreamError error 事件的数据
+type StreamError struct {
+	Message string      `json:"message"`
+	Detail  interface{} `json:"detail,omitempty"`
+}
+
+// fieldPattern 匹配已经输出完整的 "key": "value" 字段
+var fieldPattern = regexp.MustCompile(`"(\w+)"\s*:\s*"((?:[^"\\]|\\.)*)"`)
+
+// fieldScanner 从不断增长的模型输出中找出新出现的字段
+// 只保留最后一个完整字段之后的文本, 每个 token 只解析尚未匹配的部分
+type fieldScanner struct {
+	pending string
+	seen    map[string]bool
+}
+
+func (s *fieldScanner) scan(token string) (fields []StreamField) {
+	s.pending += token
+	matches := fieldPattern.FindAllStringSubmatchIndex(s.pending, -1)
+	for _, match := range matches {
+		key, value := s.pending[match[2]:match[3]], s.pending[match[4]:match[5]]
+		if s.seen[key] {
+			continue
+		}
+		s.seen[key] = true
+		fields = append(fields, StreamField{Key: key, Value: value})
+	}
+	if len(matches) > 0 {
+		s.pending = s.pending[matches[len(matches)-1][1]:]
+	}
+	return fields
+}
+
+// StreamImageNumber 以事件形式执行 ImageNumber
+func StreamImageNumber(ctx context.Context, platform, imageBase64, modelName string, opts CodeOptions, emit EmitFunc) {
+	emit(EventStarted, StreamStarted{Platform: platform, Model: modelName})
+	ctx = tool.WithTokenHandler(ctx, func(token string) {
+		emit(EventToken, token)
+	})
+	resp, err := ImageNumber(ctx, NewOcr(platform), imageBase64, modelName, opts)
+	if err != nil {
+		emitError(emit, err)
+		return
+	}
+	emit(EventResult, &api.OcrRes{Content: resp})
+}
+
+// StreamImageNumbers 以事件形式执行 ImageNumbers
+func StreamImageNumbers(ctx context.Context, platform, imageBase64, modelName string, emit EmitFunc) {
+	emit(EventStarted, StreamStarted{Platform: platform, Model: modelName})
+	ctx = tool.WithTokenHandler(ctx, func(token string) {
+		emit(EventToken, token)
+	})
+	resp, err := ImageNumbers(ctx, NewOcr(platform), imageBase64, modelName)
+	if err != nil {
+		emitError(emit, err)
+		return
+	}
+	emit(EventResult, resp)
+}
+
+// StreamImageText 以事件形式执行 ImageText
+func StreamImageText(ctx context.Context, platform, imageBase64, modelName string, languageTags bool, emit EmitFunc) {
+	emit(EventStarted, StreamStarted{Platform: platform, Model: modelName})
+	ctx = tool.WithTokenHandler(ctx, func(token string) {
+		emit(EventToken, token)
+	})
+	resp, err := ImageText(ctx, NewOcr(platform), imageBase64, modelName, languageTags)
+	if err != nil {
+		emitError(emit, err)
+		return
+	}
+	emit(EventResult, resp)
+}
+
+// StreamPassportInfo 以事件形式执行 PassportInfo, 字段输出完整后立即推送 field 事件
+func StreamPassportInfo(ctx context.Context, platform, imageBase64, modelName string, opts DocumentOptions, emit EmitFunc) {
+	emit(EventStarted, StreamStarted{Platform: platform, Model: modelName})
+	scanner := &fieldScanner{seen: map[string]bool{}}
+	ctx = tool.WithTokenHandler(ctx, func(token string) {
+		emit(EventToken, token)
+		for _, field := range scanner.scan(token) {
+			emit(EventField, field)
+		}
+	})
+	resp, err := PassportInfo(ctx, NewOcr(platform), imageBase64, modelName, opts)
+	if err != nil {
+		emitError(emit, err)
+		return
+	}
+	emit(EventResult, resp)
+}
+
+func emitError(emit EmitFunc, err error) {
+	streamErr := StreamError{Message: err.Error()}
+	var outputErr *tool.OutputError
+	if errors.As(err, &outputErr) {
+		streamErr.Detail = outputErr
+	}
+	emit(EventError, streamErr)
+}
@@ -0,0 +1,26 @@
+package ocr
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFieldScanner(t *testing.T) {
+	tokens := []string{`{"sur`, `name": "ERIKS`, `SON", "give`, `name": "AN\"NA"`, `, "surname": "OTHER", "sex": "F"}`}
+	want := [][]StreamField{
+		nil,
+		nil,
+		{{Key: "surname", Value: "ERIKSSON"}},
+		{{Key: "givename", Value: `AN\"NA`}},
+		{{Key: "sex", Value: "F"}},
+	}
+	scanner := &fieldScanner{seen: map[string]bool{}}
+	for i, token := range tokens {
+		if got := scanner.scan(token); !slices.Equal(got, want[i]) {
+			t.Errorf("scan(%q) = %v, want %v", token, got, want[i])
+		}
+	}
+	if scanner.pending != "}" {
+		t.Errorf("pending = %q, want only the text after the last field", scanner.pending)
+	}
+}
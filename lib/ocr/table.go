@@ -0,0 +1,121 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"strings"
+)
+
+var tablePrompt = "Find every table in this image and transcribe each one cell by cell, in reading order. " +
+	"For each table return title (the caption above the table, or an empty string), header_rows (the number of header rows at the top) and rows (an array of rows, each an array of cell strings, including the header rows). " +
+	"When a cell is merged across several columns or rows, repeat its value in every cell it covers so that all rows have the same number of cells. " +
+	"When a cell contains several lines of text, join them with \\n. Use an empty string for empty cells. " +
+	"Copy numbers exactly as printed. Return a JSON object with a tables array."
+
+var tableSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"tables": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"title":       map[string]interface{}{"type": "string"},
+					"header_rows": map[string]interface{}{"type": "integer"},
+					"rows": map[string]interface{}{
+						"type":  "array",
+						"items": map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}},
+					},
+				},
+				"required": []interface{}{"title", "header_rows", "rows"},
+			},
+		},
+	},
+	"required": []interface{}{"tables"},
+}
+
+// Tables 识别图片中的所有表格
+func Tables(ctx context.Context, serv provider.Provider, imageBase64, modelName string) (tables []api.TableInfo, err error) {
+	content, err := completeSchema(ctx, serv, imageBase64, modelName, tablePrompt, tableSchema)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Tables []api.TableInfo `json:"tables"`
+	}
+	if err = decodeJSON(content, &result); err != nil {
+		return nil, err
+	}
+	tables = make([]api.TableInfo, 0, len(result.Tables))
+	for _, table := range result.Tables {
+		if normalizeTable(&table) {
+			tables = append(tables, table)
+		}
+	}
+	return tables, nil
+}
+
+// normalizeTable 去掉空行并补齐列数, 将表头中横向合并后留空的单元格填充为左侧的值, 生成列名
+// 表格没有任何内容时返回 false
+func normalizeTable(table *api.TableInfo) bool {
+	table.Title = strings.TrimSpace(table.Title)
+	rows := make([][]string, 0, len(table.Rows))
+	width, emptyHeaders := 0, 0
+	for i, row := range table.Rows {
+		empty := true
+		for j := range row {
+			row[j] = strings.TrimSpace(strings.ReplaceAll(row[j], "\r\n", "\n"))
+			if row[j] != "" {
+				empty = false
+			}
+		}
+		if empty {
+			if i < table.HeaderRows {
+				emptyHeaders++
+			}
+			continue
+		}
+		rows = append(rows, row)
+		width = max(width, len(row))
+	}
+	if len(rows) == 0 {
+		return false
+	}
+	for i := range rows {
+		for len(rows[i]) < width {
+			rows[i] = append(rows[i], "")
+		}
+	}
+	table.HeaderRows = min(max(table.HeaderRows-emptyHeaders, 0), len(rows))
+
+	for i := 0; i < table.HeaderRows; i++ {
+		for j := 1; j < width; j++ {
+			if rows[i][j] == "" && rows[i][j-1] != "" && (i == 0 || rows[i-1][j] == rows[i-1][j-1]) {
+				rows[i][j] = rows[i][j-1]
+			}
+		}
+	}
+	table.Columns = make([]string, width)
+	for j := 0; j < width; j++ {
+		var parts []string
+		for i := 0; i < table.HeaderRows; i++ {
+			if value := rows[i][j]; value != "" && (len(parts) == 0 || parts[len(parts)-1] != value) {
+				parts = append(parts, value)
+			}
+		}
+		table.Columns[j] = strings.Join(parts, " / ")
+	}
+	table.Rows = rows
+	return true
+}
+
+// TableSheets 将表格转换为 xlsx 工作表, 工作表名称取表格标题
+func TableSheets(tables []api.TableInfo) []tool.XLSXSheet {
+	sheets := make([]tool.XLSXSheet, 0, len(tables))
+	for _, table := range tables {
+		sheets = append(sheets, tool.XLSXSheet{Name: table.Title, Rows: table.Rows})
+	}
+	return sheets
+}
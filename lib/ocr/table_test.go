@@ -0,0 +1,64 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"slices"
+	"testing"
+)
+
+func TestNormalizeTable(t *testing.T) {
+	tests := []struct {
+		name       string
+		table      api.TableInfo
+		headerRows int
+		columns    []string
+		rows       [][]string
+	}{
+		{
+			name:       "merged header cells",
+			table:      api.TableInfo{Title: " 销量 ", HeaderRows: 2, Rows: [][]string{{"地区", "2024", ""}, {"", "上半年", "下半年"}, {"华东", "10", "12"}}},
+			headerRows: 2,
+			columns:    []string{"地区", "2024 / 上半年", "2024 / 下半年"},
+			rows:       [][]string{{"地区", "2024", "2024"}, {"", "上半年", "下半年"}, {"华东", "10", "12"}},
+		},
+		{
+			name:       "empty row inside the header",
+			table:      api.TableInfo{HeaderRows: 2, Rows: [][]string{{"", " "}, {"名称", "数量"}, {"苹果", "2"}, {"梨", "3"}}},
+			headerRows: 1,
+			columns:    []string{"名称", "数量"},
+			rows:       [][]string{{"名称", "数量"}, {"苹果", "2"}, {"梨", "3"}},
+		},
+		{
+			name:       "empty rows in the header and the body",
+			table:      api.TableInfo{HeaderRows: 3, Rows: [][]string{{""}, {"名称", "数量"}, {"", ""}, {"苹果", "2"}, {""}, {"梨", "3"}}},
+			headerRows: 1,
+			columns:    []string{"名称", "数量"},
+			rows:       [][]string{{"名称", "数量"}, {"苹果", "2"}, {"梨", "3"}},
+		},
+		{
+			name:       "short rows are padded",
+			table:      api.TableInfo{HeaderRows: 5, Rows: [][]string{{"a\r\nb", "c"}, {"d"}}},
+			headerRows: 2,
+			columns:    []string{"a\nb / d", "c"},
+			rows:       [][]string{{"a\nb", "c"}, {"d", ""}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !normalizeTable(&tt.table) {
+				t.Fatal("normalizeTable() = false")
+			}
+			if tt.table.HeaderRows != tt.headerRows || !slices.Equal(tt.table.Columns, tt.columns) {
+				t.Errorf("normalizeTable() header rows %d columns %q, want %d %q", tt.table.HeaderRows, tt.table.Columns, tt.headerRows, tt.columns)
+			}
+			if !slices.EqualFunc(tt.table.Rows, tt.rows, slices.Equal) {
+				t.Errorf("normalizeTable() rows = %q, want %q", tt.table.Rows, tt.rows)
+			}
+		})
+	}
+
+	empty := api.TableInfo{HeaderRows: 1, Rows: [][]string{{" ", ""}}}
+	if normalizeTable(&empty) {
+		t.Error("normalizeTable() of an empty table = true")
+	}
+}
@@ -0,0 +1,266 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// templateDir 存放自定义文档模板的目录, 同名模板覆盖内置模板, 修改后下一个请求即生效
+var templateDir = "config/templates"
+
+//go:embed templates/*.yaml
+var builtinTemplates embed.FS
+
+var templateTypePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// DocumentTemplate 文档模板, 描述一种证件需要识别的字段以及提示词
+type DocumentTemplate struct {
+	Type          string            `json:"type"`
+	Description   string            `json:"description"`
+	Prompts       map[string]string `json:"prompts"` // 按语言区分的提示词, 找不到请求的语言时使用 en
+	Fields        []TemplateField   `json:"fields"`
+	Translate     *TranslateRule    `json:"translate"`
+	CountryField  string            `json:"country_field"`  // 签发国所在的字段, 用于判断纯数字日期的日月顺序
+	ValidityYears int               `json:"validity_years"` // 签发日期到有效期的最长年数, 0 表示不检查
+}
+
+// TemplateField 模板中的一个字段
+type TemplateField struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Type        string `json:"type"`        // string, date, name 或 sex, 决定 OutputProfile 如何转换该字段
+	DateFormat  string `json:"date_format"` // type 为 date 时输出的日期格式, Go layout
+	DateKind    string `json:"date_kind"`   // birth, issue 或 expiry, 决定两位年份的世纪
+	Pattern     string `json:"pattern"`     // 值需要满足的正则表达式, 不满足时给出警告
+	Case        string `json:"case"`        // upper 或 lower
+	Required    bool   `json:"required"`
+	Extended    bool   `json:"extended"` // 只在请求完整字段时识别和返回
+}
+
+// TranslateRule 识别完成后需要翻译为请求语言的字段, 由 translate.backends 配置的后端翻译
+type TranslateRule struct {
+	Fields  []string `json:"fields"`
+	Context string   `json:"context"` // 附加给翻译模型的说明, 例如值来自哪种文档
+}
+
+// DocumentOptions 按模板识别时的可选项
+type DocumentOptions struct {
+	Language  string // 提示词语言以及翻译字段的目标语言
+	Positions bool   // 同时返回每个字段在原图中的位置
+	Extended  bool   // 同时识别模板中 extended 的字段
+	Profile   api.OutputProfile
+}
+
+// DocumentResult 按模板识别的结果
+type DocumentResult struct {
+	Template     *DocumentTemplate
+	Fields       map[string]string
+	Positions    []api.KeyValueInfo
+	Validity     *api.ValidityInfo
+	Translations []api.TranslationInfo
+	Warnings     []string
+	Flags        ValidityFlags // 不在响应中输出, 供年龄核验等按问题类型判断可信度
+}
+
+// LoadTemplate 读取指定类型的文档模板, 优先使用 templateDir 中的文件
+func LoadTemplate(docType string) (*DocumentTemplate, error) {
+	if !templateTypePattern.MatchString(docType) {
+		return nil, fmt.Errorf("invalid document type %q", docType)
+	}
+	fileName := docType + ".yaml"
+	content, err := os.ReadFile(filepath.Join(templateDir, fileName))
+	if os.IsNotExist(err) {
+		content, err = builtinTemplates.ReadFile("templates/" + fileName)
+		if err != nil {
+			return nil, fmt.Errorf("document type %q is not defined", docType)
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	templateJson, err := gjson.LoadContentType(gjson.ContentTypeYaml, content)
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", fileName, err)
+	}
+	var template *DocumentTemplate
+	if err = templateJson.Scan(&template); err != nil {
+		return nil, fmt.Errorf("parse template %s: %w", fileName, err)
+	}
+	if template == nil || len(template.Fields) == 0 {
+		return nil, fmt.Errorf("template %s has no fields", fileName)
+	}
+	if template.Type == "" {
+		template.Type = docType
+	}
+	return template, nil
+}
+
+// Compact 返回去掉 extended 字段的模板副本
+func (t *DocumentTemplate) Compact() *DocumentTemplate {
+	compact := *t
+	compact.Fields = make([]TemplateField, 0, len(t.Fields))
+	for _, field := range t.Fields {
+		if !field.Extended {
+			compact.Fields = append(compact.Fields, field)
+		}
+	}
+	return &compact
+}
+
+// Schema 由模板字段生成 JSON Schema
+func (t *DocumentTemplate) Schema() map[string]interface{} {
+	properties := map[string]interface{}{}
+	for _, field := range t.Fields {
+		properties[field.Name] = map[string]interface{}{
+			"type":        "string",
+			"description": field.Description,
+		}
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+}
+
+// Prompt 生成请求模型的提示词, 字段值按证件上印刷的原文识别
+func (t *DocumentTemplate) Prompt(language string) string {
+	prompt, ok := t.Prompts[language]
+	if !ok {
+		prompt = t.Prompts["en"]
+	}
+	descriptions := make([]string, 0, len(t.Fields))
+	for _, field := range t.Fields {
+		if field.Description == "" {
+			descriptions = append(descriptions, field.Name)
+		} else {
+			descriptions = append(descriptions, fmt.Sprintf("%s (%s)", field.Name, field.Description))
+		}
+	}
+	return strings.TrimSpace(prompt + " Return a JSON object with the fields: " + strings.Join(descriptions, ", ") + ". Use an empty string for fields that are not present.")
+}
+
+// Field 返回指定名称的字段, 不存在时返回 nil
+func (t *DocumentTemplate) Field(name string) *TemplateField {
+	for i := range t.Fields {
+		if t.Fields[i].Name == name {
+			return &t.Fields[i]
+		}
+	}
+	return nil
+}
+
+// Normalize 按模板处理模型输出的字段: 大小写转换、日期格式化, 并检查必填项和正则
+// 日期的日月顺序无法确定时按签发国的习惯理解并给出警告
+func (t *DocumentTemplate) Normalize(values map[string]interface{}) *DocumentResult {
+	result := &DocumentResult{Fields: map[string]string{}}
+	var country string
+	if t.CountryField != "" {
+		country = gconv.String(values[t.CountryField])
+	}
+	for _, field := range t.Fields {
+		value := strings.TrimSpace(gconv.String(values[field.Name]))
+		if value == "" {
+			if field.Required {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s is missing", field.Name))
+			}
+			result.Fields[field.Name] = value
+			continue
+		}
+		switch field.Case {
+		case "upper":
+			value = strings.ToUpper(value)
+		case "lower":
+			value = strings.ToLower(value)
+		}
+		if field.Type == "date" && field.DateFormat != "" {
+			parsed, err := tool.ParseDate(value, tool.DateContext{Kind: tool.DateKind(field.DateKind), Country: country})
+			if err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s %q is not a recognized date", field.Name, value))
+			} else {
+				if parsed.Ambiguous {
+					result.Warnings = append(result.Warnings, fmt.Sprintf("%s %q is ambiguous: %s", field.Name, value, parsed.Reason))
+				}
+				value = parsed.Time.Format(field.DateFormat)
+			}
+		}
+		if field.Pattern != "" {
+			if re, err := regexp.Compile(field.Pattern); err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s has invalid pattern %s", field.Name, field.Pattern))
+			} else if !re.MatchString(value) {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s %q does not match %s", field.Name, value, field.Pattern))
+			}
+		}
+		result.Fields[field.Name] = value
+	}
+	return result
+}
+
+// RecognizeDocument 按 docType 对应的模板识别图片, 推算有效性、应用 opts.Profile 并翻译字段
+func RecognizeDocument(ctx context.Context, serv provider.Provider, docType, imageBase64, modelName string, opts DocumentOptions) (*DocumentResult, error) {
+	result, err := recognizeDocument(ctx, serv, docType, imageBase64, modelName, opts)
+	if err != nil {
+		return nil, err
+	}
+	result.finish(ctx, serv, modelName, opts)
+	return result, nil
+}
+
+// finish 识别和校验之后的统一处理: 推算有效性、应用输出格式, 最后翻译字段
+func (r *DocumentResult) finish(ctx context.Context, serv provider.Provider, modelName string, opts DocumentOptions) {
+	r.checkValidity()
+	r.ApplyProfile(opts.Profile)
+	r.translate(ctx, serv, modelName, opts)
+}
+
+// recognizeDocument 识别并按模板处理字段, 不做 finish 中的处理, 供需要在此基础上继续校验的调用方使用
+func recognizeDocument(ctx context.Context, serv provider.Provider, docType, imageBase64, modelName string, opts DocumentOptions) (*DocumentResult, error) {
+	template, err := LoadTemplate(docType)
+	if err != nil {
+		return nil, err
+	}
+	if !opts.Extended {
+		template = template.Compact()
+	}
+	prompt, schema := template.Prompt(opts.Language), template.Schema()
+	boxFormat := provider.BoxFormatOf(serv, modelName)
+	if opts.Positions {
+		prompt += positionsPrompt(boxFormat)
+		schema = positionsSchema(schema, template.Fields)
+	}
+	content, err := completeSchema(ctx, serv, imageBase64, modelName, prompt, schema)
+	if err != nil {
+		return nil, err
+	}
+	// 模型可能把值输出为数字, 通过 gjson 解析后统一转换为字符串
+	valuesJson, err := gjson.DecodeToJson(content)
+	if err != nil {
+		return nil, fmt.Errorf("model output is not a json object: %w", err)
+	}
+	result := template.Normalize(valuesJson.Map())
+	result.Template = template
+	if opts.Positions {
+		positions, warnings := fieldPositions(ctx, imageBase64, boxFormat, valuesJson.Get("positions").Map(), result.Fields)
+		result.Positions = positions
+		result.Warnings = append(result.Warnings, warnings...)
+	}
+	return result, nil
+}
+
+func isEnglish(language string) bool {
+	switch strings.ToLower(language) {
+	case "", "en", "english":
+		return true
+	}
+	return false
+}
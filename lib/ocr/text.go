@@ -0,0 +1,73 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"strings"
+)
+
+var textPrompt = "Transcribe all text in this image in reading order as Markdown. " +
+	"Keep the layout: use # headings for titles, separate paragraphs with blank lines, keep bulleted and numbered lists, and write tables as Markdown tables. " +
+	"Do not translate, summarize or correct the text, and do not add any commentary."
+
+var textLinesPrompt = textPrompt + " Return a JSON object with two fields: markdown (the Markdown transcription) and lines " +
+	"(an array with one object per line of text in reading order, each with text and language, the BCP 47 tag of the line's language such as en or zh-Hans)."
+
+var textLinesSchema = map[string]interface{}{
+	"type": "object",
+	"properties": map[string]interface{}{
+		"markdown": map[string]interface{}{"type": "string"},
+		"lines": map[string]interface{}{
+			"type": "array",
+			"items": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"text":     map[string]interface{}{"type": "string"},
+					"language": map[string]interface{}{"type": "string"},
+				},
+				"required": []interface{}{"text", "language"},
+			},
+		},
+	},
+	"required": []interface{}{"markdown", "lines"},
+}
+
+// ImageText 识别图片中的全部文字, 返回 Markdown 和由其转换的纯文本
+// languageTags 为 true 时同时返回按阅读顺序排列的每行文字及其语言
+func ImageText(ctx context.Context, serv provider.Provider, imageBase64, modelName string, languageTags bool) (resp *api.OcrRes, err error) {
+	resp = &api.OcrRes{}
+	if languageTags {
+		var result struct {
+			Markdown string         `json:"markdown"`
+			Lines    []api.TextLine `json:"lines"`
+		}
+		content, err := completeSchema(ctx, serv, imageBase64, modelName, textLinesPrompt, textLinesSchema)
+		if err != nil {
+			return nil, err
+		}
+		if err = decodeJSON(content, &result); err != nil {
+			return nil, err
+		}
+		resp.Markdown = tool.StripCodeFence(result.Markdown)
+		for _, line := range result.Lines {
+			if line.Text = strings.TrimSpace(line.Text); line.Text != "" {
+				resp.Lines = append(resp.Lines, line)
+			}
+		}
+	} else {
+		text, err := complete(ctx, serv, &provider.Request{
+			Model:       modelName,
+			Images:      []string{imageBase64},
+			Instruction: textPrompt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp.Markdown = tool.StripCodeFence(text)
+	}
+	resp.Text = tool.MarkdownToText(resp.Markdown)
+	resp.Content = resp.Text
+	return resp, nil
+}
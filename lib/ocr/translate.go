@@ -0,0 +1,271 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogf/gf/v2/encoding/gjson"
+	"github.com/gogf/gf/v2/frame/g"
+	"github.com/gogf/gf/v2/os/gcache"
+	"github.com/gogf/gf/v2/os/gcfg"
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// glossaryFile 本地词表, 顶层按目标语言分组, 每组是 原文 -> 译文, 修改后下一个请求即生效
+var glossaryFile = "config/glossary.yaml"
+
+var (
+	// defaultTranslateBackends 未配置 translate.backends 时先查词表, 再用识别所用的模型翻译
+	defaultTranslateBackends = []string{"glossary", "llm"}
+	translationCache         = gcache.New(10000)
+	translationCacheDuration = 24 * time.Hour
+)
+
+// Translator 翻译后端, 返回能够翻译的值的译文, 不能翻译的值不出现在结果中
+type Translator interface {
+	Name() string
+	Translate(ctx context.Context, values []string, language string) (map[string]string, error)
+}
+
+// cacheableTranslator 由译文可以缓存的后端实现, 返回区分后端实例的缓存键前缀
+type cacheableTranslator interface {
+	CacheKey() string
+}
+
+// GlossaryTranslator 按 glossaryFile 中的词表精确匹配, 忽略大小写和首尾空白
+type GlossaryTranslator struct{}
+
+func (GlossaryTranslator) Name() string {
+	return "glossary"
+}
+
+func (GlossaryTranslator) Translate(ctx context.Context, values []string, language string) (map[string]string, error) {
+	content, err := os.ReadFile(glossaryFile)
+	if os.IsNotExist(err) {
+		g.Log().Warningf(ctx, "glossary %s does not exist, skip glossary translation", glossaryFile)
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	glossaryJson, err := gjson.LoadContentType(gjson.ContentTypeYaml, content)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", glossaryFile, err)
+	}
+	entries := map[string]string{}
+	for source, target := range glossaryJson.Get(language).Map() {
+		entries[strings.ToUpper(strings.TrimSpace(source))] = gconv.String(target)
+	}
+	translations := map[string]string{}
+	for _, value := range values {
+		if target, ok := entries[strings.ToUpper(strings.TrimSpace(value))]; ok {
+			translations[value] = target
+		}
+	}
+	return translations, nil
+}
+
+// LLMTranslator 由模型翻译, 只发送文本不发送图片
+type LLMTranslator struct {
+	Backend  string // 状态中显示的后端名称, llm 或 provider
+	Platform string // 平台名称, 与 Model 和 Context 一起区分缓存的译文
+	Provider provider.Provider
+	Model    string
+	Context  string // 附加给模型的说明, 例如值来自哪种文档
+}
+
+func (t LLMTranslator) Name() string {
+	return t.Backend
+}
+
+func (t LLMTranslator) CacheKey() string {
+	return fmt.Sprintf("%s/%s/%s", t.Platform, t.Model, t.Context)
+}
+
+func (t LLMTranslator) Translate(ctx context.Context, values []string, language string) (map[string]string, error) {
+	// 以序号作为键, 避免原文中的特殊字符影响 JSON 键名
+	source := map[string]string{}
+	properties := map[string]interface{}{}
+	for i, value := range values {
+		key := strconv.Itoa(i + 1)
+		source[key] = value
+		properties[key] = map[string]interface{}{"type": "string"}
+	}
+	sourceJson, err := json.Marshal(source)
+	if err != nil {
+		return nil, err
+	}
+	instruction := strings.TrimSpace(fmt.Sprintf("Translate every value of this JSON object into %s. %s "+
+		"Keep numbers, dates and codes unchanged. Return a JSON object with the same keys.\n%s", language, t.Context, sourceJson))
+	text, err := complete(ctx, t.Provider, &provider.Request{
+		Model:       t.Model,
+		Instruction: instruction,
+		JSON:        true,
+		Schema:      map[string]interface{}{"type": "object", "properties": properties},
+	})
+	if err != nil {
+		return nil, err
+	}
+	translatedJson, err := gjson.DecodeToJson(tool.ExtractJSON(text))
+	if err != nil {
+		return nil, fmt.Errorf("model output is not a json object: %w", err)
+	}
+	translations := map[string]string{}
+	for i, value := range values {
+		if target := strings.TrimSpace(translatedJson.Get(strconv.Itoa(i + 1)).String()); target != "" {
+			translations[value] = target
+		}
+	}
+	return translations, nil
+}
+
+// translatorsFor 按配置 translate.backends 依次创建翻译后端
+// llm 使用识别所用的平台和模型, provider 使用 translate.platform 和 translate.model 指定的平台和模型
+func translatorsFor(ctx context.Context, serv provider.Provider, modelName, translateContext string) ([]Translator, error) {
+	adapter, err := gcfg.NewAdapterFile("config")
+	if err != nil {
+		return nil, err
+	}
+	if err = adapter.AddPath("config/"); err != nil {
+		return nil, err
+	}
+	config, err := adapter.Get(ctx, "translate")
+	if err != nil {
+		return nil, err
+	}
+	configJson := gjson.New(config)
+	backends := configJson.Get("backends").Strings()
+	if len(backends) == 0 {
+		backends = defaultTranslateBackends
+	}
+
+	var translators []Translator
+	for _, backend := range backends {
+		switch backend {
+		case "glossary":
+			translators = append(translators, GlossaryTranslator{})
+		case "llm":
+			translators = append(translators, LLMTranslator{Backend: backend, Platform: platformOf(serv), Provider: serv, Model: modelName, Context: translateContext})
+		case "provider":
+			platformServ := NewOcr(configJson.Get("platform").String())
+			translators = append(translators, LLMTranslator{
+				Backend:  backend,
+				Platform: platformOf(platformServ),
+				Provider: platformServ,
+				Model:    configJson.Get("model").String(),
+				Context:  translateContext,
+			})
+		default:
+			return nil, fmt.Errorf("unknown translate backend %q", backend)
+		}
+	}
+	return translators, nil
+}
+
+// TranslateFields 将 values 中 fields 列出的字段翻译为 language, 译文写回 values
+// 依次使用 translators, 前一个后端没有翻译的值交给下一个; 所有后端都没有翻译时保留原文并标记为 failed
+func TranslateFields(ctx context.Context, translators []Translator, values map[string]string, fields []string, language string) []api.TranslationInfo {
+	var pending []string
+	for _, field := range fields {
+		if value := values[field]; value != "" && !slices.Contains(pending, value) {
+			pending = append(pending, value)
+		}
+	}
+
+	type translation struct {
+		text, backend string
+		cached        bool
+	}
+	done := map[string]translation{}
+	var lastErr error
+	for _, translator := range translators {
+		var uncached []string
+		cacheable, canCache := translator.(cacheableTranslator)
+		for _, value := range pending {
+			if _, ok := done[value]; ok {
+				continue
+			}
+			if canCache {
+				if cached, _ := translationCache.Get(ctx, cacheable.CacheKey()+"|"+language+"|"+value); !cached.IsNil() {
+					done[value] = translation{cached.String(), translator.Name(), true}
+					continue
+				}
+			}
+			uncached = append(uncached, value)
+		}
+		if len(uncached) == 0 {
+			continue
+		}
+		translations, err := translator.Translate(ctx, uncached, language)
+		if err != nil {
+			g.Log().Warningf(ctx, "translate backend %s: %s", translator.Name(), err.Error())
+			lastErr = err
+			continue
+		}
+		for value, text := range translations {
+			done[value] = translation{text, translator.Name(), false}
+			if canCache {
+				_ = translationCache.Set(ctx, cacheable.CacheKey()+"|"+language+"|"+value, text, translationCacheDuration)
+			}
+		}
+	}
+
+	infos := make([]api.TranslationInfo, 0, len(fields))
+	for _, field := range fields {
+		info := api.TranslationInfo{Field: field, Original: values[field], Language: language}
+		result, ok := done[info.Original]
+		switch {
+		case info.Original == "":
+			info.Status = "skipped"
+		case ok:
+			info.Status, info.Translated, info.Backend, info.Cached = "translated", result.text, result.backend, result.cached
+			values[field] = result.text
+		default:
+			info.Status, info.Error = "failed", "no backend translated the value"
+			if lastErr != nil {
+				info.Error = lastErr.Error()
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// translate 按模板的翻译规则把字段翻译为 opts.Language, 英文或模板没有翻译规则时不处理
+// 已按 OutputProfile 编码的性别字段不再翻译
+func (r *DocumentResult) translate(ctx context.Context, serv provider.Provider, modelName string, opts DocumentOptions) {
+	rule := r.Template.Translate
+	if rule == nil || len(rule.Fields) == 0 || isEnglish(opts.Language) {
+		return
+	}
+	var fields []string
+	for _, name := range rule.Fields {
+		if field := r.Template.Field(name); field != nil && !(field.Type == "sex" && opts.Profile.SexFormat != "") {
+			fields = append(fields, name)
+		}
+	}
+	translators, err := translatorsFor(ctx, serv, modelName, rule.Context)
+	if err == nil && len(translators) == 0 {
+		err = errors.New("no translate backend is configured")
+	}
+	if err != nil {
+		for _, field := range fields {
+			info := api.TranslationInfo{Field: field, Original: r.Fields[field], Language: opts.Language, Status: "skipped"}
+			if info.Original != "" {
+				info.Status, info.Error = "failed", err.Error()
+			}
+			r.Translations = append(r.Translations, info)
+		}
+		return
+	}
+	r.Translations = TranslateFields(ctx, translators, r.Fields, fields, opts.Language)
+}
@@ -0,0 +1,130 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/tool"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// idCardValidityYears 居民身份证的有效期为 5 年、10 年、20 年或长期
+const idCardValidityYears = 20
+
+// documentDates 证件上与有效性相关的日期, 零值表示缺少或无法解析
+type documentDates struct {
+	Birth, Issue, Expiry time.Time
+}
+
+// ValidityFlags 影响核验结论可信度的问题, 与对应的警告同时产生, 供调用方判断而不必匹配警告文本
+type ValidityFlags struct {
+	BirthDateSuspect bool // 出生日期在未来、与签发日期或有效期矛盾, 或与身份证号码不符
+	MRZFailed        bool // 护照机读区缺失、无法解析或有校验位未通过
+	IdNumberFailed   bool // 身份证号码未通过 GB 11643 校验
+}
+
+// deriveValidity 由日期推算是否过期、剩余天数和年龄, 并检查日期的先后顺序和有效期长度
+// maxYears 为 0 时不检查有效期长度; 所有日期都缺少时返回 nil
+func deriveValidity(dates documentDates, maxYears int, now time.Time) (validity *api.ValidityInfo, flags ValidityFlags, warnings []string) {
+	if dates.Birth.IsZero() && dates.Issue.IsZero() && dates.Expiry.IsZero() {
+		return nil, flags, nil
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	validity = &api.ValidityInfo{}
+
+	if !dates.Expiry.IsZero() {
+		// 有效期当天仍然有效
+		expired := dates.Expiry.Before(today)
+		days := int(dates.Expiry.Sub(today).Hours() / 24)
+		validity.Expired, validity.DaysUntilExpiry = &expired, &days
+		if expired {
+			warnings = append(warnings, fmt.Sprintf("document expired %d days ago", -days))
+		}
+	}
+	if !dates.Birth.IsZero() && !dates.Birth.After(today) {
+		age := today.Year() - dates.Birth.Year()
+		if today.Month() < dates.Birth.Month() || (today.Month() == dates.Birth.Month() && today.Day() < dates.Birth.Day()) {
+			age--
+		}
+		over18, over21 := age >= 18, age >= 21
+		validity.Age, validity.Over18, validity.Over21 = &age, &over18, &over21
+	}
+
+	var inconsistencies []string
+	if dates.Birth.After(today) {
+		inconsistencies = append(inconsistencies, "birth date is in the future")
+		flags.BirthDateSuspect = true
+	}
+	if dates.Issue.After(today) {
+		inconsistencies = append(inconsistencies, "issue date is in the future")
+	}
+	if !dates.Birth.IsZero() && !dates.Issue.IsZero() && dates.Issue.Before(dates.Birth) {
+		inconsistencies = append(inconsistencies, "issue date is before birth date")
+		flags.BirthDateSuspect = true
+	}
+	if !dates.Birth.IsZero() && !dates.Expiry.IsZero() && !dates.Expiry.After(dates.Birth) {
+		inconsistencies = append(inconsistencies, "expiry date is not after birth date")
+		flags.BirthDateSuspect = true
+	}
+	if !dates.Issue.IsZero() && !dates.Expiry.IsZero() {
+		if !dates.Expiry.After(dates.Issue) {
+			inconsistencies = append(inconsistencies, "expiry date is not after issue date")
+		} else if maxYears > 0 && dates.Expiry.After(dates.Issue.AddDate(maxYears+1, 0, 0)) {
+			// 允许一年的误差, 部分国家会把旧证剩余的有效期加到新证上
+			inconsistencies = append(inconsistencies, fmt.Sprintf("validity period is longer than %d years", maxYears))
+		}
+	}
+	validity.Consistent = len(inconsistencies) == 0
+	return validity, flags, append(warnings, inconsistencies...)
+}
+
+// checkValidity 按模板中日期字段的 date_kind 推算有效性并记录到 r.Flags, 需要在应用 OutputProfile 之前调用
+func (r *DocumentResult) checkValidity() {
+	if r.Template == nil {
+		return
+	}
+	var dates documentDates
+	for _, field := range r.Template.Fields {
+		if field.Type != "date" || r.Fields[field.Name] == "" {
+			continue
+		}
+		date, err := time.Parse(field.DateFormat, r.Fields[field.Name])
+		if err != nil {
+			continue
+		}
+		switch {
+		case field.DateKind == "birth" && dates.Birth.IsZero():
+			dates.Birth = date
+		case field.DateKind == "issue" && dates.Issue.IsZero():
+			dates.Issue = date
+		case field.DateKind == "expiry" && dates.Expiry.IsZero():
+			dates.Expiry = date
+		}
+	}
+	validity, flags, warnings := deriveValidity(dates, r.Template.ValidityYears, time.Now())
+	r.Validity = validity
+	r.Flags.BirthDateSuspect = r.Flags.BirthDateSuspect || flags.BirthDateSuspect
+	r.Warnings = append(r.Warnings, warnings...)
+}
+
+// IdCardValidity 推算居民身份证的有效性, 人像面只有出生日期, 国徽面只有有效期限
+// 人像面的号码未通过校验或与出生日期不符时在 flags 中标出, 对应的警告由 tool.CheckIdCard 给出
+func IdCardValidity(info *api.IdCardInfo) (*api.ValidityInfo, ValidityFlags, []string) {
+	var dates documentDates
+	layout := "2006.01.02"
+	dates.Birth, _ = time.Parse(layout, info.BirthDate)
+	if parts := strings.Split(info.ValidPeriod, "-"); len(parts) == 2 {
+		dates.Issue, _ = time.Parse(layout, strings.TrimSpace(parts[0]))
+		// 长期有效的身份证没有结束日期
+		dates.Expiry, _ = time.Parse(layout, strings.TrimSpace(parts[1]))
+	}
+	validity, flags, warnings := deriveValidity(dates, idCardValidityYears, time.Now())
+	if info.IdNumber != "" {
+		if err := tool.ValidateIdNumber(info.IdNumber); err != nil {
+			flags.IdNumberFailed = true
+		} else if !dates.Birth.IsZero() && dates.Birth.Format("20060102") != strings.ToUpper(strings.TrimSpace(info.IdNumber))[6:14] {
+			flags.BirthDateSuspect = true
+		}
+	}
+	return validity, flags, warnings
+}
@@ -0,0 +1,180 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestDeriveValidity(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	date := func(value string) time.Time {
+		parsed, _ := time.Parse("2006-01-02", value)
+		return parsed
+	}
+	tests := []struct {
+		name       string
+		dates      documentDates
+		maxYears   int
+		expired    *bool
+		days       *int
+		age        *int
+		consistent bool
+		suspect    bool
+		warnings   []string
+	}{
+		{
+			name:     "valid passport",
+			dates:    documentDates{Birth: date("1996-06-08"), Issue: date("2020-01-15"), Expiry: date("2030-01-14")},
+			maxYears: 10,
+			expired:  ptr(false), days: ptr(1183), age: ptr(30), consistent: true,
+		},
+		{
+			name:    "expires today",
+			dates:   documentDates{Expiry: date("2026-10-19")},
+			expired: ptr(false), days: ptr(0), consistent: true,
+		},
+		{
+			name:    "expired yesterday",
+			dates:   documentDates{Expiry: date("2026-10-18")},
+			expired: ptr(true), days: ptr(-1), consistent: true,
+			warnings: []string{"document expired 1 days ago"},
+		},
+		{
+			name:  "18th birthday today",
+			dates: documentDates{Birth: date("2008-10-19")},
+			age:   ptr(18), consistent: true,
+		},
+		{
+			name:  "18th birthday tomorrow",
+			dates: documentDates{Birth: date("2008-10-20")},
+			age:   ptr(17), consistent: true,
+		},
+		{
+			name:  "born on 29 February",
+			dates: documentDates{Birth: date("2008-02-29")},
+			age:   ptr(18), consistent: true,
+		},
+		{
+			name:     "birth in the future",
+			dates:    documentDates{Birth: date("2027-01-01")},
+			suspect:  true,
+			warnings: []string{"birth date is in the future"},
+		},
+		{
+			name:    "inconsistent order",
+			dates:   documentDates{Birth: date("2000-01-01"), Issue: date("1999-01-01"), Expiry: date("1998-01-01")},
+			expired: ptr(true), days: ptr(-10518), age: ptr(26), suspect: true,
+			warnings: []string{
+				"document expired 10518 days ago",
+				"issue date is before birth date",
+				"expiry date is not after birth date",
+				"expiry date is not after issue date",
+			},
+		},
+		{
+			name:     "validity period too long",
+			dates:    documentDates{Issue: date("2020-01-01"), Expiry: date("2032-01-02")},
+			maxYears: 10,
+			expired:  ptr(false), days: ptr(1901),
+			warnings: []string{"validity period is longer than 10 years"},
+		},
+		{
+			name:     "one extra year is tolerated",
+			dates:    documentDates{Issue: date("2020-01-01"), Expiry: date("2031-01-01")},
+			maxYears: 10,
+			expired:  ptr(false), days: ptr(1535), consistent: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validity, flags, warnings := deriveValidity(tt.dates, tt.maxYears, now)
+			if validity == nil {
+				t.Fatal("deriveValidity() = nil")
+			}
+			if !equalPtr(validity.Expired, tt.expired) || !equalPtr(validity.DaysUntilExpiry, tt.days) || !equalPtr(validity.Age, tt.age) {
+				t.Errorf("deriveValidity() expired %v days %v age %v, want %v %v %v",
+					deref(validity.Expired), deref(validity.DaysUntilExpiry), deref(validity.Age), deref(tt.expired), deref(tt.days), deref(tt.age))
+			}
+			if tt.age != nil && (*validity.Over18 != (*tt.age >= 18) || *validity.Over21 != (*tt.age >= 21)) {
+				t.Errorf("deriveValidity() over18 %v over21 %v for age %d", *validity.Over18, *validity.Over21, *tt.age)
+			}
+			if validity.Consistent != tt.consistent || !slices.Equal(warnings, tt.warnings) {
+				t.Errorf("deriveValidity() consistent %v warnings %q, want %v %q", validity.Consistent, warnings, tt.consistent, tt.warnings)
+			}
+			if flags.BirthDateSuspect != tt.suspect {
+				t.Errorf("deriveValidity() BirthDateSuspect = %v, want %v", flags.BirthDateSuspect, tt.suspect)
+			}
+		})
+	}
+
+	if validity, _, warnings := deriveValidity(documentDates{}, 10, now); validity != nil || warnings != nil {
+		t.Errorf("deriveValidity() without dates = %v, %q, want nil", validity, warnings)
+	}
+}
+
+func TestCheckValidity(t *testing.T) {
+	result := &DocumentResult{
+		Template: &DocumentTemplate{ValidityYears: 10, Fields: []TemplateField{
+			{Name: "birth_date", Type: "date", DateFormat: "02/01/2006", DateKind: "birth"},
+			{Name: "issue_date", Type: "date", DateFormat: "02/01/2006", DateKind: "issue"},
+			{Name: "expiry_date", Type: "date", DateFormat: "02/01/2006", DateKind: "expiry"},
+			{Name: "name"},
+		}},
+		Fields: map[string]string{"birth_date": "08/06/1996", "issue_date": "not a date", "expiry_date": "01/01/1990", "name": "01/01/2020"},
+	}
+	result.checkValidity()
+	if result.Validity == nil || result.Validity.Age == nil || result.Validity.Expired == nil || !*result.Validity.Expired {
+		t.Fatalf("checkValidity() = %+v, want age and expired", result.Validity)
+	}
+	if result.Validity.Consistent {
+		t.Errorf("checkValidity() consistent = true, want false")
+	}
+	if len(result.Warnings) == 0 || result.Warnings[len(result.Warnings)-1] != "expiry date is not after birth date" {
+		t.Errorf("checkValidity() warnings = %q", result.Warnings)
+	}
+	if !result.Flags.BirthDateSuspect {
+		t.Errorf("checkValidity() BirthDateSuspect = false, want true")
+	}
+
+	empty := &DocumentResult{Template: result.Template, Fields: map[string]string{"birth_date": ""}}
+	if empty.checkValidity(); empty.Validity != nil {
+		t.Errorf("checkValidity() without dates = %+v, want nil", empty.Validity)
+	}
+}
+
+func TestIdCardValidityFlags(t *testing.T) {
+	tests := []struct {
+		name  string
+		info  api.IdCardInfo
+		flags ValidityFlags
+	}{
+		{"valid number", api.IdCardInfo{IdNumber: "440304199606081234", BirthDate: "1996.06.08"}, ValidityFlags{}},
+		{"check code mismatch", api.IdCardInfo{IdNumber: "440304199606081235", BirthDate: "1996.06.08"}, ValidityFlags{IdNumberFailed: true}},
+		{"birth date differs from number", api.IdCardInfo{IdNumber: "440304199606081234", BirthDate: "1996.06.09"}, ValidityFlags{BirthDateSuspect: true}},
+		{"back side", api.IdCardInfo{ValidPeriod: "2020.01.01-2040.01.01"}, ValidityFlags{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, flags, _ := IdCardValidity(&tt.info); flags != tt.flags {
+				t.Errorf("IdCardValidity() flags = %+v, want %+v", flags, tt.flags)
+			}
+		})
+	}
+}
+
+func ptr[T any](value T) *T {
+	return &value
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
+
+func deref[T any](value *T) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
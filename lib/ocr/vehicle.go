@@ -0,0 +1,28 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"strings"
+)
+
+// VehicleLicenseInfo 识别机动车行驶证
+func VehicleLicenseInfo(ctx context.Context, serv provider.Provider, imageBase64, modelName string) (resp *api.VehicleLicenseInfo, err error) {
+	prompt := "Extract the following fields from this Chinese motor vehicle registration certificate (行驶证): plate_number, vehicle_type, owner, address, use_character, model, vin (17 characters), engine_number, register_date (format yyyy.mm.dd), issue_date (format yyyy.mm.dd). Keep Chinese text as printed. Return as JSON object."
+	var info api.VehicleLicenseInfo
+	if err = completeJSON(ctx, serv, imageBase64, modelName, prompt, &info); err != nil {
+		return nil, err
+	}
+
+	info.Vin = strings.ToUpper(strings.ReplaceAll(info.Vin, " ", ""))
+	outputFormat := "2006.01.02"
+	if converted, err := tool.ParseAndFormatDate(info.RegisterDate, outputFormat); err == nil {
+		info.RegisterDate = converted
+	}
+	if converted, err := tool.ParseAndFormatDate(info.IssueDate, outputFormat); err == nil {
+		info.IssueDate = converted
+	}
+	return &info, nil
+}
@@ -0,0 +1,95 @@
+package ocr
+
+import (
+	"codeocr/api"
+	"codeocr/lib/ocr/provider"
+	"codeocr/lib/tool"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// errAgeUnverifiable 年龄核验的错误不包含模型输出, 避免证件信息进入响应和日志
+var errAgeUnverifiable = errors.New("document could not be read for age verification")
+
+// VerifyAge 判断证件持有人是否年满 threshold 岁, 支持护照、驾驶证和中国居民身份证人像面
+// 只返回结论、证件类型、有效期状态和可信度, 不返回也不记录姓名、号码和出生日期
+func VerifyAge(ctx context.Context, serv provider.Provider, imageBase64, modelName string, threshold int) (resp *api.VerifyAgeRes, err error) {
+	classification, err := ClassifyDocument(ctx, serv, imageBase64, modelName)
+	if err != nil {
+		return nil, verifyError(err)
+	}
+
+	var (
+		validity *api.ValidityInfo
+		flags    ValidityFlags
+	)
+	switch {
+	case classification.DocumentType == DocumentPassport:
+		passport, err := recognizePassport(ctx, serv, imageBase64, modelName, DocumentOptions{})
+		if err != nil {
+			return nil, verifyError(err)
+		}
+		validity, flags = passport.Validity, passport.Flags
+	case classification.DocumentType == DocumentDrivingLicense:
+		license, err := RecognizeDocument(ctx, serv, "driving-license", imageBase64, modelName, DocumentOptions{Language: "en"})
+		if err != nil {
+			return nil, verifyError(err)
+		}
+		validity, flags = license.Validity, license.Flags
+	// 只有中国居民身份证按 GB 11643 识别, 国家未知的身份证不支持
+	case classification.DocumentType == DocumentIdCard && classification.Country == "CHN":
+		if classification.Side == "back" {
+			return nil, errors.New("the back of an ID card has no birth date, send the portrait side")
+		}
+		idCard, _, err := IdCardInfo(ctx, serv, imageBase64, modelName, "front")
+		if err != nil {
+			return nil, verifyError(err)
+		}
+		validity, flags, _ = IdCardValidity(idCard)
+	default:
+		return nil, fmt.Errorf("document type %s (country: %s) is not supported for age verification", classification.DocumentType, classification.Country)
+	}
+
+	resp = &api.VerifyAgeRes{
+		Status:       "unverifiable",
+		DocumentType: classification.DocumentType,
+		ExpiryStatus: "unknown",
+	}
+	if validity != nil && validity.Expired != nil {
+		resp.ExpiryStatus = "valid"
+		if *validity.Expired {
+			resp.ExpiryStatus = "expired"
+		}
+	}
+	// 读不出出生日期时没有结论, 不能当作未满 threshold 岁
+	if validity == nil || validity.Age == nil {
+		return resp, nil
+	}
+	resp.Verified = *validity.Age >= threshold
+	resp.Status = "underage"
+	if resp.Verified {
+		resp.Status = "verified"
+	}
+
+	// 可信度以分类可信度为基础, 日期矛盾或出生日期、机读区、证件号码存在问题时降低
+	confidence := classification.Confidence
+	if !validity.Consistent {
+		confidence *= 0.5
+	}
+	if flags.BirthDateSuspect || flags.MRZFailed || flags.IdNumberFailed {
+		confidence *= 0.7
+	}
+	resp.Confidence = math.Round(confidence*100) / 100
+	return resp, nil
+}
+
+// verifyError 保留不含识别内容的 *tool.OutputError, 其他错误替换为 errAgeUnverifiable
+func verifyError(err error) error {
+	var outputErr *tool.OutputError
+	if errors.As(err, &outputErr) {
+		return outputErr
+	}
+	return errAgeUnverifiable
+}
@@ -0,0 +1,32 @@
+package tool
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseAmount 解析金额或数量, 忽略货币符号、千分位和空格
+func ParseAmount(amount string) (float64, error) {
+	replacer := strings.NewReplacer("¥", "", "￥", "", "$", "", ",", "", "，", "", " ", "")
+	return strconv.ParseFloat(replacer.Replace(strings.TrimSpace(amount)), 64)
+}
+
+// ParseRate 解析税率, "13%" 和 "0.13" 都返回 0.13
+func ParseRate(rate string) (float64, error) {
+	rate = strings.TrimSpace(rate)
+	if strings.HasSuffix(rate, "%") {
+		value, err := strconv.ParseFloat(strings.TrimSuffix(rate, "%"), 64)
+		return value / 100, err
+	}
+	value, err := strconv.ParseFloat(rate, 64)
+	if err == nil && value > 1 {
+		value /= 100
+	}
+	return value, err
+}
+
+// AmountEqual 判断两个金额在分位舍入误差内是否相等
+func AmountEqual(a, b float64) bool {
+	return math.Abs(math.Round(a*100)-math.Round(b*100)) <= 1
+}
@@ -0,0 +1,84 @@
+package tool
+
+import (
+	"strconv"
+	"strings"
+)
+
+// cardNetworks 卡组织 BIN 号段, 按前缀范围匹配, 较长的前缀写在前面
+var cardNetworks = []struct {
+	network  string
+	from, to int
+	digits   int
+}{
+	{"Mir", 2200, 2204, 4},
+	{"Mastercard", 2221, 2720, 4},
+	{"JCB", 3528, 3589, 4},
+	{"Discover", 6011, 6011, 4},
+	{"American Express", 34, 34, 2},
+	{"American Express", 37, 37, 2},
+	{"Diners Club", 300, 305, 3},
+	{"Diners Club", 36, 36, 2},
+	{"Diners Club", 38, 39, 2},
+	{"Discover", 644, 649, 3},
+	{"Visa", 4, 4, 1},
+	{"Mastercard", 51, 55, 2},
+	{"Maestro", 50, 50, 2},
+	{"Maestro", 56, 58, 2},
+	{"UnionPay", 62, 62, 2},
+	{"Discover", 65, 65, 2},
+}
+
+// CardNumberDigits 去掉卡号中的空格和分隔符, 只保留数字
+func CardNumberDigits(cardNumber string) string {
+	return strings.Join(ExtractNumbers(cardNumber), "")
+}
+
+// LuhnValid 使用 Luhn 算法校验卡号
+func LuhnValid(cardNumber string) bool {
+	if len(cardNumber) < 12 || len(cardNumber) > 19 {
+		return false
+	}
+	sum := 0
+	double := false
+	for i := len(cardNumber) - 1; i >= 0; i-- {
+		if cardNumber[i] < '0' || cardNumber[i] > '9' {
+			return false
+		}
+		digit := int(cardNumber[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// CardNetwork 根据 BIN 前缀判断卡组织, 无法识别时返回空字符串
+func CardNetwork(cardNumber string) string {
+	for _, item := range cardNetworks {
+		if len(cardNumber) < item.digits {
+			continue
+		}
+		prefix, err := strconv.Atoi(cardNumber[:item.digits])
+		if err != nil {
+			return ""
+		}
+		if prefix >= item.from && prefix <= item.to {
+			return item.network
+		}
+	}
+	return ""
+}
+
+// MaskCardNumber 只保留卡号前 6 位和后 4 位, 用于日志输出
+func MaskCardNumber(cardNumber string) string {
+	if len(cardNumber) <= 10 {
+		return strings.Repeat("*", len(cardNumber))
+	}
+	return cardNumber[:6] + strings.Repeat("*", len(cardNumber)-10) + cardNumber[len(cardNumber)-4:]
+}
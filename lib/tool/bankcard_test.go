@@ -0,0 +1,66 @@
+package tool
+
+import "testing"
+
+func TestLuhnValid(t *testing.T) {
+	tests := []struct {
+		cardNumber string
+		valid      bool
+	}{
+		{"4111111111111111", true},
+		{"5555555555554444", true},
+		{"378282246310005", true},
+		{"6011111111111117", true},
+		{"3530111333300000", true},
+		{"6212345678901265", true},
+		{"4111111111111112", false},
+		{"5555555555554443", false},
+		{"41111111111", false},
+		{"41111111111111111111", false},
+		{"4111 1111 1111 1111", false},
+	}
+	for _, tt := range tests {
+		if got := LuhnValid(tt.cardNumber); got != tt.valid {
+			t.Errorf("LuhnValid(%q) = %v, want %v", tt.cardNumber, got, tt.valid)
+		}
+	}
+}
+
+func TestCardNetwork(t *testing.T) {
+	tests := []struct {
+		cardNumber, network string
+	}{
+		{"4111111111111111", "Visa"},
+		{"5555555555554444", "Mastercard"},
+		{"2221000000000009", "Mastercard"},
+		{"2720990000000007", "Mastercard"},
+		{"2200000000000004", "Mir"},
+		{"378282246310005", "American Express"},
+		{"341111111111111", "American Express"},
+		{"30569309025904", "Diners Club"},
+		{"36227206271667", "Diners Club"},
+		{"6011111111111117", "Discover"},
+		{"6445644564456445", "Discover"},
+		{"6500000000000002", "Discover"},
+		{"3530111333300000", "JCB"},
+		{"6212345678901265", "UnionPay"},
+		{"5018000000000009", "Maestro"},
+		{"1234567890123452", ""},
+		{"2721000000000000", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := CardNetwork(tt.cardNumber); got != tt.network {
+			t.Errorf("CardNetwork(%q) = %q, want %q", tt.cardNumber, got, tt.network)
+		}
+	}
+}
+
+func TestCardNumberDigits(t *testing.T) {
+	if got := CardNumberDigits("6212 3456-7890 1265"); got != "6212345678901265" {
+		t.Errorf("CardNumberDigits() = %q", got)
+	}
+	if got := MaskCardNumber("6212345678901265"); got != "621234******1265" {
+		t.Errorf("MaskCardNumber() = %q", got)
+	}
+}
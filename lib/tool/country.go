@@ -0,0 +1,386 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Country ISO 3166-1 国家或地区, 以及 ICAO 9303 使用的国籍代码
+type Country struct {
+	Alpha2 string `json:"alpha2"` // ICAO 特殊代码 (如无国籍 XXA) 没有 ISO 代码时为空
+	Alpha3 string `json:"alpha3"`
+	ICAO   string `json:"icao"` // 机读区使用的代码, 除德国为 D 外与 Alpha3 相同
+	Name   string `json:"name"`
+}
+
+// Code 返回统一使用的三字母代码: 有 ISO alpha-3 时使用 alpha-3, 否则使用 ICAO 代码
+func (c *Country) Code() string {
+	if c.Alpha3 != "" {
+		return c.Alpha3
+	}
+	return c.ICAO
+}
+
+// countryEntry 国家表中的一行: alpha-2, alpha-3, 英文名称, 其他名称 (正式名称、国籍形容词、中文名称等)
+type countryEntry struct {
+	alpha2, alpha3, name string
+	aliases              []string
+}
+
+// icaoCountries ICAO 9303 第 3 部分定义的不属于 ISO 3166-1 的代码
+var icaoCountries = []Country{
+	{Alpha2: "DE", Alpha3: "DEU", ICAO: "D", Name: "Germany"},
+	{Alpha2: "GB", Alpha3: "GBR", ICAO: "GBD", Name: "British Overseas Territories Citizen"},
+	{Alpha2: "GB", Alpha3: "GBR", ICAO: "GBN", Name: "British National (Overseas)"},
+	{Alpha2: "GB", Alpha3: "GBR", ICAO: "GBO", Name: "British Overseas Citizen"},
+	{Alpha2: "GB", Alpha3: "GBR", ICAO: "GBP", Name: "British Protected Person"},
+	{Alpha2: "GB", Alpha3: "GBR", ICAO: "GBS", Name: "British Subject"},
+	{ICAO: "EUE", Name: "European Union"},
+	{ICAO: "UNO", Name: "United Nations Organization"},
+	{ICAO: "UNA", Name: "Specialized Agency of the United Nations"},
+	{ICAO: "UNK", Name: "Resident of Kosovo (UNMIK)"},
+	{ICAO: "RKS", Name: "Kosovo"},
+	{ICAO: "XBA", Name: "African Development Bank"},
+	{ICAO: "XIM", Name: "African Export-Import Bank"},
+	{ICAO: "XCC", Name: "Caribbean Community"},
+	{ICAO: "XCO", Name: "Common Market for Eastern and Southern Africa"},
+	{ICAO: "XEC", Name: "Economic Community of West African States"},
+	{ICAO: "XPO", Name: "Interpol"},
+	{ICAO: "XES", Name: "Organization of Eastern Caribbean States"},
+	{ICAO: "XMP", Name: "Parliamentary Assembly of the Mediterranean"},
+	{ICAO: "XOM", Name: "Sovereign Military Order of Malta"},
+	{ICAO: "XDC", Name: "Southern African Development Community"},
+	{ICAO: "XXA", Name: "Stateless person"},
+	{ICAO: "XXB", Name: "Refugee (1951 Convention)"},
+	{ICAO: "XXC", Name: "Refugee (other)"},
+	{ICAO: "XXX", Name: "Unspecified nationality"},
+	{ICAO: "UTO", Name: "Utopia"}, // ICAO 9303 样例证件使用的虚构国家
+}
+
+var icaoAliases = map[string][]string{
+	"XXA": {"STATELESS", "无国籍"},
+	"XXB": {"REFUGEE"},
+	"EUE": {"EUROPEAN"},
+	"UNO": {"UNITED NATIONS", "UN"},
+	"RKS": {"KOSOVO", "KOSOVAR"},
+	"UTO": {"UTOPIAN"},
+}
+
+var countryIndex = buildCountryIndex()
+
+// LookupCountry 根据代码、英文或中文名称、国籍形容词查找国家, 不区分大小写
+// 支持 ISO 3166-1 alpha-2, alpha-3 和 ICAO 9303 代码, 例如 "CHINESE", "China", "CHN", "CN", "中国", "D"
+func LookupCountry(value string) (*Country, error) {
+	key := countryKey(value)
+	if key == "" {
+		return nil, fmt.Errorf("country is empty")
+	}
+	if country, ok := countryIndex[key]; ok {
+		return country, nil
+	}
+	return nil, fmt.Errorf("unknown country %q", value)
+}
+
+// countryKey 转换为大写并去掉标点, 用于比较名称
+func countryKey(value string) string {
+	value = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToUpper(r)
+		case unicode.IsSpace(r) || r == '-' || r == ',':
+			return ' '
+		}
+		return -1
+	}, value)
+	value = strings.Join(strings.Fields(value), " ")
+	return strings.TrimPrefix(value, "THE ")
+}
+
+func buildCountryIndex() map[string]*Country {
+	index := map[string]*Country{}
+	add := func(key string, country *Country) {
+		// 先加入的条目优先, 代码不会被名称覆盖
+		if key = countryKey(key); key != "" && index[key] == nil {
+			index[key] = country
+		}
+	}
+	for _, entry := range countries {
+		country := &Country{Alpha2: entry.alpha2, Alpha3: entry.alpha3, ICAO: entry.alpha3, Name: entry.name}
+		if entry.alpha3 == "DEU" {
+			country.ICAO = "D"
+		}
+		add(entry.alpha3, country)
+		add(entry.alpha2, country)
+	}
+	for i := range icaoCountries {
+		add(icaoCountries[i].ICAO, &icaoCountries[i])
+	}
+	for _, entry := range countries {
+		country := index[entry.alpha3]
+		add(entry.name, country)
+		for _, alias := range entry.aliases {
+			add(alias, country)
+		}
+	}
+	for i := range icaoCountries {
+		add(icaoCountries[i].Name, &icaoCountries[i])
+		for _, alias := range icaoAliases[icaoCountries[i].ICAO] {
+			add(alias, &icaoCountries[i])
+		}
+	}
+	return index
+}
+
+// countries ISO 3166-1 国家和地区表
+var countries = []countryEntry{
+	{"AD", "AND", "Andorra", []string{"Principality of Andorra", "ANDORRAN", "安道尔"}},
+	{"AE", "ARE", "United Arab Emirates", []string{"EMIRATI", "UAE", "阿联酋"}},
+	{"AF", "AFG", "Afghanistan", []string{"Islamic Republic of Afghanistan", "AFGHAN", "阿富汗"}},
+	{"AG", "ATG", "Antigua and Barbuda", []string{"安提瓜和巴布达"}},
+	{"AI", "AIA", "Anguilla", []string{"安圭拉"}},
+	{"AL", "ALB", "Albania", []string{"Republic of Albania", "ALBANIAN", "阿尔巴尼亚"}},
+	{"AM", "ARM", "Armenia", []string{"Republic of Armenia", "ARMENIAN", "亚美尼亚"}},
+	{"AO", "AGO", "Angola", []string{"Republic of Angola", "ANGOLAN", "安哥拉"}},
+	{"AQ", "ATA", "Antarctica", []string{"南极洲"}},
+	{"AR", "ARG", "Argentina", []string{"Argentine Republic", "ARGENTINE", "ARGENTINIAN", "阿根廷"}},
+	{"AS", "ASM", "American Samoa", []string{"美属萨摩亚"}},
+	{"AT", "AUT", "Austria", []string{"Republic of Austria", "AUSTRIAN", "奥地利"}},
+	{"AU", "AUS", "Australia", []string{"AUSTRALIAN", "澳大利亚"}},
+	{"AW", "ABW", "Aruba", []string{"阿鲁巴"}},
+	{"AX", "ALA", "Åland Islands", []string{"奥兰群岛"}},
+	{"AZ", "AZE", "Azerbaijan", []string{"Republic of Azerbaijan", "AZERBAIJANI", "阿塞拜疆"}},
+	{"BA", "BIH", "Bosnia and Herzegovina", []string{"Republic of Bosnia and Herzegovina", "BOSNIAN", "波斯尼亚和黑塞哥维那"}},
+	{"BB", "BRB", "Barbados", []string{"巴巴多斯"}},
+	{"BD", "BGD", "Bangladesh", []string{"People's Republic of Bangladesh", "BANGLADESHI", "孟加拉"}},
+	{"BE", "BEL", "Belgium", []string{"Kingdom of Belgium", "BELGIAN", "比利时"}},
+	{"BF", "BFA", "Burkina Faso", []string{"布基纳法索"}},
+	{"BG", "BGR", "Bulgaria", []string{"Republic of Bulgaria", "BULGARIAN", "保加利亚"}},
+	{"BH", "BHR", "Bahrain", []string{"Kingdom of Bahrain", "BAHRAINI", "巴林"}},
+	{"BI", "BDI", "Burundi", []string{"Republic of Burundi", "布隆迪"}},
+	{"BJ", "BEN", "Benin", []string{"Republic of Benin", "贝宁"}},
+	{"BL", "BLM", "Saint Barthélemy", []string{"圣巴泰勒米岛"}},
+	{"BM", "BMU", "Bermuda", []string{"百慕大"}},
+	{"BN", "BRN", "Brunei Darussalam", []string{"BRUNEIAN", "文莱"}},
+	{"BO", "BOL", "Bolivia, Plurinational State of", []string{"Bolivia", "Plurinational State of Bolivia", "BOLIVIAN", "玻利维亚共和国", "波利维亚", "玻利维亚"}},
+	{"BQ", "BES", "Bonaire, Sint Eustatius and Saba", []string{"博奈尔、圣尤斯特歇斯岛和萨巴"}},
+	{"BR", "BRA", "Brazil", []string{"Federative Republic of Brazil", "BRAZILIAN", "巴西"}},
+	{"BS", "BHS", "Bahamas", []string{"Commonwealth of the Bahamas", "巴哈马"}},
+	{"BT", "BTN", "Bhutan", []string{"Kingdom of Bhutan", "BHUTANESE", "不丹"}},
+	{"BV", "BVT", "Bouvet Island", []string{"布维群岛"}},
+	{"BW", "BWA", "Botswana", []string{"Republic of Botswana", "BOTSWANAN", "MOTSWANA", "博兹瓦那"}},
+	{"BY", "BLR", "Belarus", []string{"Republic of Belarus", "BELARUSIAN", "白俄罗斯"}},
+	{"BZ", "BLZ", "Belize", []string{"伯利兹"}},
+	{"CA", "CAN", "Canada", []string{"CANADIAN", "加拿大"}},
+	{"CC", "CCK", "Cocos (Keeling) Islands", []string{"科科斯群岛"}},
+	{"CD", "COD", "Congo, The Democratic Republic of the", []string{"CONGOLESE (DRC)", "DR CONGO", "DRC", "刚果民主共和国"}},
+	{"CF", "CAF", "Central African Republic", []string{"中非"}},
+	{"CG", "COG", "Congo", []string{"Republic of the Congo", "CONGOLESE", "刚果"}},
+	{"CH", "CHE", "Switzerland", []string{"Swiss Confederation", "SWISS", "瑞士"}},
+	{"CI", "CIV", "Côte d'Ivoire", []string{"Republic of Côte d'Ivoire", "IVORIAN", "科特迪瓦"}},
+	{"CK", "COK", "Cook Islands", []string{"库克群岛"}},
+	{"CL", "CHL", "Chile", []string{"Republic of Chile", "CHILEAN", "智利"}},
+	{"CM", "CMR", "Cameroon", []string{"Republic of Cameroon", "CAMEROONIAN", "喀麦隆"}},
+	{"CN", "CHN", "China", []string{"People's Republic of China", "CHINESE", "中国", "中华人民共和国", "中国籍"}},
+	{"CO", "COL", "Colombia", []string{"Republic of Colombia", "COLOMBIAN", "哥伦比亚"}},
+	{"CR", "CRI", "Costa Rica", []string{"Republic of Costa Rica", "COSTA RICAN", "哥斯达黎加"}},
+	{"CU", "CUB", "Cuba", []string{"Republic of Cuba", "CUBAN", "古巴"}},
+	{"CV", "CPV", "Cabo Verde", []string{"Republic of Cabo Verde", "CAPE VERDEAN", "佛得角"}},
+	{"CW", "CUW", "Curaçao", []string{"库拉索"}},
+	{"CX", "CXR", "Christmas Island", []string{"圣诞岛"}},
+	{"CY", "CYP", "Cyprus", []string{"Republic of Cyprus", "CYPRIOT", "塞浦路斯"}},
+	{"CZ", "CZE", "Czechia", []string{"Czech Republic", "CZECH", "捷克"}},
+	{"DE", "DEU", "Germany", []string{"Federal Republic of Germany", "GERMAN", "DEUTSCH", "DEUTSCHLAND", "BUNDESREPUBLIK DEUTSCHLAND", "德国"}},
+	{"DJ", "DJI", "Djibouti", []string{"Republic of Djibouti", "吉布提"}},
+	{"DK", "DNK", "Denmark", []string{"Kingdom of Denmark", "DANISH", "丹麦"}},
+	{"DM", "DMA", "Dominica", []string{"Commonwealth of Dominica", "多米尼克"}},
+	{"DO", "DOM", "Dominican Republic", []string{"DOMINICAN", "多米尼加共和国"}},
+	{"DZ", "DZA", "Algeria", []string{"People's Democratic Republic of Algeria", "ALGERIAN", "阿尔及利亚"}},
+	{"EC", "ECU", "Ecuador", []string{"Republic of Ecuador", "ECUADORIAN", "厄瓜多尔"}},
+	{"EE", "EST", "Estonia", []string{"Republic of Estonia", "ESTONIAN", "爱沙尼亚"}},
+	{"EG", "EGY", "Egypt", []string{"Arab Republic of Egypt", "EGYPTIAN", "埃及"}},
+	{"EH", "ESH", "Western Sahara", []string{"西撒哈拉"}},
+	{"ER", "ERI", "Eritrea", []string{"the State of Eritrea", "厄立特里亚"}},
+	{"ES", "ESP", "Spain", []string{"Kingdom of Spain", "SPANISH", "ESPAÑOLA", "ESPANOLA", "西班牙"}},
+	{"ET", "ETH", "Ethiopia", []string{"Federal Democratic Republic of Ethiopia", "ETHIOPIAN", "埃塞俄比亚"}},
+	{"FI", "FIN", "Finland", []string{"Republic of Finland", "FINNISH", "芬兰"}},
+	{"FJ", "FJI", "Fiji", []string{"Republic of Fiji", "FIJIAN", "斐济"}},
+	{"FK", "FLK", "Falkland Islands (Malvinas)", []string{"福克兰群岛(马尔维纳斯)"}},
+	{"FM", "FSM", "Micronesia, Federated States of", []string{"Federated States of Micronesia", "密克罗尼西亚"}},
+	{"FO", "FRO", "Faroe Islands", []string{"法罗群岛"}},
+	{"FR", "FRA", "France", []string{"French Republic", "FRENCH", "FRANÇAISE", "FRANCAISE", "法国"}},
+	{"GA", "GAB", "Gabon", []string{"Gabonese Republic", "加蓬"}},
+	{"GB", "GBR", "United Kingdom", []string{"United Kingdom of Great Britain and Northern Ireland", "BRITISH", "BRITISH CITIZEN", "UK", "GREAT BRITAIN", "ENGLAND", "ENGLISH", "SCOTTISH", "WELSH", "英国"}},
+	{"GD", "GRD", "Grenada", []string{"格林纳达"}},
+	{"GE", "GEO", "Georgia", []string{"GEORGIAN", "格鲁吉亚"}},
+	{"GF", "GUF", "French Guiana", []string{"法属圭亚那"}},
+	{"GG", "GGY", "Guernsey", []string{"根西岛"}},
+	{"GH", "GHA", "Ghana", []string{"Republic of Ghana", "GHANAIAN", "加纳"}},
+	{"GI", "GIB", "Gibraltar", []string{"直布罗陀"}},
+	{"GL", "GRL", "Greenland", []string{"格陵兰"}},
+	{"GM", "GMB", "Gambia", []string{"Republic of the Gambia", "冈比亚"}},
+	{"GN", "GIN", "Guinea", []string{"Republic of Guinea", "几内亚"}},
+	{"GP", "GLP", "Guadeloupe", []string{"瓜德罗普"}},
+	{"GQ", "GNQ", "Equatorial Guinea", []string{"Republic of Equatorial Guinea", "赤道几内亚"}},
+	{"GR", "GRC", "Greece", []string{"Hellenic Republic", "GREEK", "HELLENIC", "希腊"}},
+	{"GS", "SGS", "South Georgia and the South Sandwich Islands", []string{"南乔治亚岛和南桑德韦奇岛"}},
+	{"GT", "GTM", "Guatemala", []string{"Republic of Guatemala", "GUATEMALAN", "瓜地马拉"}},
+	{"GU", "GUM", "Guam", []string{"关岛"}},
+	{"GW", "GNB", "Guinea-Bissau", []string{"Republic of Guinea-Bissau", "几内亚比绍"}},
+	{"GY", "GUY", "Guyana", []string{"Republic of Guyana", "圭亚那"}},
+	{"HK", "HKG", "Hong Kong", []string{"Hong Kong Special Administrative Region of China", "HONG KONG", "HKSAR", "HONG KONG SAR", "HONG KONG SAR CHINA", "HONG KONG CHINA", "香港", "中国香港", "香港特别行政区"}},
+	{"HM", "HMD", "Heard Island and McDonald Islands", []string{"赫德岛与麦克唐纳群岛"}},
+	{"HN", "HND", "Honduras", []string{"Republic of Honduras", "HONDURAN", "洪都拉斯"}},
+	{"HR", "HRV", "Croatia", []string{"Republic of Croatia", "CROATIAN", "克罗地亚"}},
+	{"HT", "HTI", "Haiti", []string{"Republic of Haiti", "海地"}},
+	{"HU", "HUN", "Hungary", []string{"HUNGARIAN", "匈牙利"}},
+	{"ID", "IDN", "Indonesia", []string{"Republic of Indonesia", "INDONESIAN", "印度尼西亚"}},
+	{"IE", "IRL", "Ireland", []string{"IRISH", "爱尔兰"}},
+	{"IL", "ISR", "Israel", []string{"State of Israel", "ISRAELI", "以色列"}},
+	{"IM", "IMN", "Isle of Man", []string{"曼岛"}},
+	{"IN", "IND", "India", []string{"Republic of India", "INDIAN", "印度"}},
+	{"IO", "IOT", "British Indian Ocean Territory", []string{"英属印度洋领地"}},
+	{"IQ", "IRQ", "Iraq", []string{"Republic of Iraq", "IRAQI", "伊拉克"}},
+	{"IR", "IRN", "Iran, Islamic Republic of", []string{"Iran", "Islamic Republic of Iran", "IRANIAN", "PERSIAN", "伊朗伊斯兰共和国", "伊朗"}},
+	{"IS", "ISL", "Iceland", []string{"Republic of Iceland", "ICELANDIC", "冰岛"}},
+	{"IT", "ITA", "Italy", []string{"Italian Republic", "ITALIAN", "ITALIANA", "意大利"}},
+	{"JE", "JEY", "Jersey", []string{"泽西岛"}},
+	{"JM", "JAM", "Jamaica", []string{"JAMAICAN", "牙买加"}},
+	{"JO", "JOR", "Jordan", []string{"Hashemite Kingdom of Jordan", "JORDANIAN", "约旦"}},
+	{"JP", "JPN", "Japan", []string{"JAPANESE", "JAPAN", "日本"}},
+	{"KE", "KEN", "Kenya", []string{"Republic of Kenya", "KENYAN", "肯尼亚"}},
+	{"KG", "KGZ", "Kyrgyzstan", []string{"Kyrgyz Republic", "KYRGYZ", "吉尔吉斯坦"}},
+	{"KH", "KHM", "Cambodia", []string{"Kingdom of Cambodia", "CAMBODIAN", "柬埔塞"}},
+	{"KI", "KIR", "Kiribati", []string{"Republic of Kiribati", "基里巴斯"}},
+	{"KM", "COM", "Comoros", []string{"Union of the Comoros", "科摩罗"}},
+	{"KN", "KNA", "Saint Kitts and Nevis", []string{"圣基茨和尼维斯"}},
+	{"KP", "PRK", "Korea, Democratic People's Republic of", []string{"North Korea", "Democratic People's Republic of Korea", "NORTH KOREAN", "朝鲜民主主义人民共和国", "朝鲜"}},
+	{"KR", "KOR", "Korea, Republic of", []string{"South Korea", "KOREAN", "SOUTH KOREAN", "KOREA", "REPUBLIC OF KOREA", "大韩民国", "韩国", "南韩"}},
+	{"KW", "KWT", "Kuwait", []string{"State of Kuwait", "KUWAITI", "科威特"}},
+	{"KY", "CYM", "Cayman Islands", []string{"开曼群岛"}},
+	{"KZ", "KAZ", "Kazakhstan", []string{"Republic of Kazakhstan", "KAZAKH", "KAZAKHSTANI", "哈萨克斯坦"}},
+	{"LA", "LAO", "Lao People's Democratic Republic", []string{"Laos", "LAO", "LAOTIAN", "老挝人民民主共和国", "老挝"}},
+	{"LB", "LBN", "Lebanon", []string{"Lebanese Republic", "LEBANESE", "黎巴嫩"}},
+	{"LC", "LCA", "Saint Lucia", []string{"圣路西亚"}},
+	{"LI", "LIE", "Liechtenstein", []string{"Principality of Liechtenstein", "LIECHTENSTEINER", "列支敦士登"}},
+	{"LK", "LKA", "Sri Lanka", []string{"Democratic Socialist Republic of Sri Lanka", "SRI LANKAN", "斯里兰卡"}},
+	{"LR", "LBR", "Liberia", []string{"Republic of Liberia", "利比里亚"}},
+	{"LS", "LSO", "Lesotho", []string{"Kingdom of Lesotho", "莱索托"}},
+	{"LT", "LTU", "Lithuania", []string{"Republic of Lithuania", "LITHUANIAN", "立陶宛"}},
+	{"LU", "LUX", "Luxembourg", []string{"Grand Duchy of Luxembourg", "LUXEMBOURGISH", "卢森堡"}},
+	{"LV", "LVA", "Latvia", []string{"Republic of Latvia", "LATVIAN", "拉脱维亚"}},
+	{"LY", "LBY", "Libya", []string{"LIBYAN", "利比亚"}},
+	{"MA", "MAR", "Morocco", []string{"Kingdom of Morocco", "MOROCCAN", "摩洛哥"}},
+	{"MC", "MCO", "Monaco", []string{"Principality of Monaco", "MONEGASQUE", "摩纳哥"}},
+	{"MD", "MDA", "Moldova, Republic of", []string{"Moldova", "Republic of Moldova", "MOLDOVAN", "摩尔多瓦共和国", "摩尔多瓦"}},
+	{"ME", "MNE", "Montenegro", []string{"MONTENEGRIN", "黑山"}},
+	{"MF", "MAF", "Saint Martin (French part)", []string{"法属圣马丁"}},
+	{"MG", "MDG", "Madagascar", []string{"Republic of Madagascar", "马达加斯加"}},
+	{"MH", "MHL", "Marshall Islands", []string{"Republic of the Marshall Islands", "马绍尔群岛"}},
+	{"MK", "MKD", "North Macedonia", []string{"Republic of North Macedonia", "MACEDONIAN", "北马其顿"}},
+	{"ML", "MLI", "Mali", []string{"Republic of Mali", "马里"}},
+	{"MM", "MMR", "Myanmar", []string{"Republic of Myanmar", "MYANMAR", "BURMESE", "BURMA", "缅甸"}},
+	{"MN", "MNG", "Mongolia", []string{"MONGOLIAN", "蒙古"}},
+	{"MO", "MAC", "Macao", []string{"Macao Special Administrative Region of China", "MACANESE", "MACAO SAR", "MACAU", "MACAO SAR CHINA", "MACAO CHINA", "MACAU SAR", "澳门", "中国澳门", "澳门特别行政区"}},
+	{"MP", "MNP", "Northern Mariana Islands", []string{"Commonwealth of the Northern Mariana Islands", "北马里亚纳群岛"}},
+	{"MQ", "MTQ", "Martinique", []string{"马提尼克"}},
+	{"MR", "MRT", "Mauritania", []string{"Islamic Republic of Mauritania", "毛里塔尼亚"}},
+	{"MS", "MSR", "Montserrat", []string{"蒙塞拉特岛"}},
+	{"MT", "MLT", "Malta", []string{"Republic of Malta", "MALTESE", "马尔他"}},
+	{"MU", "MUS", "Mauritius", []string{"Republic of Mauritius", "毛里求斯"}},
+	{"MV", "MDV", "Maldives", []string{"Republic of Maldives", "MALDIVIAN", "马尔代夫"}},
+	{"MW", "MWI", "Malawi", []string{"Republic of Malawi", "马拉维"}},
+	{"MX", "MEX", "Mexico", []string{"United Mexican States", "MEXICAN", "MEXICANA", "墨西哥"}},
+	{"MY", "MYS", "Malaysia", []string{"MALAYSIAN", "马来西亚"}},
+	{"MZ", "MOZ", "Mozambique", []string{"Republic of Mozambique", "MOZAMBICAN", "莫桑比克"}},
+	{"NA", "NAM", "Namibia", []string{"Republic of Namibia", "NAMIBIAN", "纳米比亚"}},
+	{"NC", "NCL", "New Caledonia", []string{"新喀里多尼亚"}},
+	{"NE", "NER", "Niger", []string{"Republic of the Niger", "尼日尔"}},
+	{"NF", "NFK", "Norfolk Island", []string{"诺福克岛"}},
+	{"NG", "NGA", "Nigeria", []string{"Federal Republic of Nigeria", "NIGERIAN", "尼日利亚"}},
+	{"NI", "NIC", "Nicaragua", []string{"Republic of Nicaragua", "NICARAGUAN", "尼加拉瓜"}},
+	{"NL", "NLD", "Netherlands", []string{"Kingdom of the Netherlands", "DUTCH", "NETHERLANDER", "HOLLAND", "荷兰"}},
+	{"NO", "NOR", "Norway", []string{"Kingdom of Norway", "NORWEGIAN", "挪威"}},
+	{"NP", "NPL", "Nepal", []string{"Federal Democratic Republic of Nepal", "NEPALI", "NEPALESE", "尼泊尔"}},
+	{"NR", "NRU", "Nauru", []string{"Republic of Nauru", "瑙鲁"}},
+	{"NU", "NIU", "Niue", []string{"纽埃"}},
+	{"NZ", "NZL", "New Zealand", []string{"NEW ZEALANDER", "NEW ZEALAND", "新西兰"}},
+	{"OM", "OMN", "Oman", []string{"Sultanate of Oman", "OMANI", "阿曼"}},
+	{"PA", "PAN", "Panama", []string{"Republic of Panama", "PANAMANIAN", "巴拿马"}},
+	{"PE", "PER", "Peru", []string{"Republic of Peru", "PERUVIAN", "秘鲁"}},
+	{"PF", "PYF", "French Polynesia", []string{"法属玻利尼西亚"}},
+	{"PG", "PNG", "Papua New Guinea", []string{"Independent State of Papua New Guinea", "PAPUA NEW GUINEAN", "巴布亚新几内亚"}},
+	{"PH", "PHL", "Philippines", []string{"Republic of the Philippines", "FILIPINO", "PHILIPPINE", "菲律宾"}},
+	{"PK", "PAK", "Pakistan", []string{"Islamic Republic of Pakistan", "PAKISTANI", "巴基斯坦"}},
+	{"PL", "POL", "Poland", []string{"Republic of Poland", "POLISH", "波兰"}},
+	{"PM", "SPM", "Saint Pierre and Miquelon", []string{"圣皮埃尔和密克隆"}},
+	{"PN", "PCN", "Pitcairn", []string{"皮特克恩"}},
+	{"PR", "PRI", "Puerto Rico", []string{"PUERTO RICAN", "波多黎各"}},
+	{"PS", "PSE", "Palestine, State of", []string{"the State of Palestine", "PALESTINIAN", "巴勒斯坦"}},
+	{"PT", "PRT", "Portugal", []string{"Portuguese Republic", "PORTUGUESE", "葡萄牙"}},
+	{"PW", "PLW", "Palau", []string{"Republic of Palau", "帕劳"}},
+	{"PY", "PRY", "Paraguay", []string{"Republic of Paraguay", "PARAGUAYAN", "巴拉圭"}},
+	{"QA", "QAT", "Qatar", []string{"State of Qatar", "QATARI", "卡塔尔"}},
+	{"RE", "REU", "Réunion", []string{"留尼汪"}},
+	{"RO", "ROU", "Romania", []string{"ROMANIAN", "罗马尼亚"}},
+	{"RS", "SRB", "Serbia", []string{"Republic of Serbia", "SERBIAN", "塞尔维亚"}},
+	{"RU", "RUS", "Russian Federation", []string{"RUSSIAN", "RUSSIA", "俄罗斯", "俄罗斯联邦"}},
+	{"RW", "RWA", "Rwanda", []string{"Rwandese Republic", "RWANDAN", "卢旺达"}},
+	{"SA", "SAU", "Saudi Arabia", []string{"Kingdom of Saudi Arabia", "SAUDI", "SAUDI ARABIAN", "沙特阿拉伯"}},
+	{"SB", "SLB", "Solomon Islands", []string{"所罗门群岛"}},
+	{"SC", "SYC", "Seychelles", []string{"Republic of Seychelles", "塞舌尔"}},
+	{"SD", "SDN", "Sudan", []string{"Republic of the Sudan", "SUDANESE", "苏丹"}},
+	{"SE", "SWE", "Sweden", []string{"Kingdom of Sweden", "SWEDISH", "瑞典"}},
+	{"SG", "SGP", "Singapore", []string{"Republic of Singapore", "SINGAPOREAN", "新加坡"}},
+	{"SH", "SHN", "Saint Helena, Ascension and Tristan da Cunha", []string{"圣赫勒拿-阿森松-特里斯坦达库尼亚"}},
+	{"SI", "SVN", "Slovenia", []string{"Republic of Slovenia", "SLOVENIAN", "SLOVENE", "斯洛文尼亚"}},
+	{"SJ", "SJM", "Svalbard and Jan Mayen", []string{"斯瓦尔巴特和扬马延岛"}},
+	{"SK", "SVK", "Slovakia", []string{"Slovak Republic", "SLOVAK", "斯洛伐克"}},
+	{"SL", "SLE", "Sierra Leone", []string{"Republic of Sierra Leone", "塞拉利昂"}},
+	{"SM", "SMR", "San Marino", []string{"Republic of San Marino", "SAMMARINESE", "圣马力诺市"}},
+	{"SN", "SEN", "Senegal", []string{"Republic of Senegal", "SENEGALESE", "塞内加尔"}},
+	{"SO", "SOM", "Somalia", []string{"Federal Republic of Somalia", "索马里"}},
+	{"SR", "SUR", "Suriname", []string{"Republic of Suriname", "苏里南"}},
+	{"SS", "SSD", "South Sudan", []string{"Republic of South Sudan", "南苏丹"}},
+	{"ST", "STP", "Sao Tome and Principe", []string{"Democratic Republic of Sao Tome and Principe", "圣多美和普林西比"}},
+	{"SV", "SLV", "El Salvador", []string{"Republic of El Salvador", "SALVADORAN", "萨尔瓦多"}},
+	{"SX", "SXM", "Sint Maarten (Dutch part)", []string{"荷属圣马丁"}},
+	{"SY", "SYR", "Syrian Arab Republic", []string{"Syria", "SYRIAN", "阿拉伯叙利亚共和国", "叙利亚"}},
+	{"SZ", "SWZ", "Eswatini", []string{"Kingdom of Eswatini", "斯威士兰"}},
+	{"TC", "TCA", "Turks and Caicos Islands", []string{"特克斯和凯科斯群岛"}},
+	{"TD", "TCD", "Chad", []string{"Republic of Chad", "乍得"}},
+	{"TF", "ATF", "French Southern Territories", []string{"法属南半球领地"}},
+	{"TG", "TGO", "Togo", []string{"Togolese Republic", "多哥"}},
+	{"TH", "THA", "Thailand", []string{"Kingdom of Thailand", "THAI", "泰国"}},
+	{"TJ", "TJK", "Tajikistan", []string{"Republic of Tajikistan", "TAJIK", "塔吉克斯坦"}},
+	{"TK", "TKL", "Tokelau", []string{"托克劳"}},
+	{"TL", "TLS", "Timor-Leste", []string{"Democratic Republic of Timor-Leste", "东帝汶"}},
+	{"TM", "TKM", "Turkmenistan", []string{"TURKMEN", "土库曼斯坦"}},
+	{"TN", "TUN", "Tunisia", []string{"Republic of Tunisia", "TUNISIAN", "突尼斯"}},
+	{"TO", "TON", "Tonga", []string{"Kingdom of Tonga", "汤加"}},
+	{"TR", "TUR", "Türkiye", []string{"Republic of Türkiye", "TURKISH", "TURKEY", "土耳其"}},
+	{"TT", "TTO", "Trinidad and Tobago", []string{"Republic of Trinidad and Tobago", "TRINIDADIAN", "特里尼达和多巴哥"}},
+	{"TV", "TUV", "Tuvalu", []string{"图瓦卢"}},
+	{"TW", "TWN", "Taiwan, Province of China", []string{"Taiwan", "TAIWANESE", "REPUBLIC OF CHINA", "中国台湾省", "台湾", "中国台湾"}},
+	{"TZ", "TZA", "Tanzania, United Republic of", []string{"Tanzania", "United Republic of Tanzania", "TANZANIAN", "坦桑尼亚"}},
+	{"UA", "UKR", "Ukraine", []string{"UKRAINIAN", "乌克兰"}},
+	{"UG", "UGA", "Uganda", []string{"Republic of Uganda", "UGANDAN", "乌干达"}},
+	{"UM", "UMI", "United States Minor Outlying Islands", []string{"美国本土外小岛屿"}},
+	{"US", "USA", "United States", []string{"United States of America", "AMERICAN", "USA", "US", "UNITED STATES OF AMERICA", "美国", "美利坚合众国"}},
+	{"UY", "URY", "Uruguay", []string{"Eastern Republic of Uruguay", "URUGUAYAN", "乌拉圭"}},
+	{"UZ", "UZB", "Uzbekistan", []string{"Republic of Uzbekistan", "UZBEK", "乌兹别克斯坦"}},
+	{"VA", "VAT", "Holy See (Vatican City State)", []string{"VATICAN", "HOLY SEE", "梵地冈"}},
+	{"VC", "VCT", "Saint Vincent and the Grenadines", []string{"圣文森特和格林纳丁斯"}},
+	{"VE", "VEN", "Venezuela, Bolivarian Republic of", []string{"Venezuela", "Bolivarian Republic of Venezuela", "VENEZUELAN", "委内瑞拉玻利瓦尔共和国", "委内瑞拉"}},
+	{"VG", "VGB", "Virgin Islands, British", []string{"British Virgin Islands", "英属维尔京群岛"}},
+	{"VI", "VIR", "Virgin Islands, U.S.", []string{"Virgin Islands of the United States", "美属维尔京群岛"}},
+	{"VN", "VNM", "Viet Nam", []string{"Vietnam", "Socialist Republic of Viet Nam", "VIETNAMESE", "VIETNAM", "越南"}},
+	{"VU", "VUT", "Vanuatu", []string{"Republic of Vanuatu", "瓦努阿图"}},
+	{"WF", "WLF", "Wallis and Futuna", []string{"瓦利斯和富图纳"}},
+	{"WS", "WSM", "Samoa", []string{"Independent State of Samoa", "萨摩亚"}},
+	{"YE", "YEM", "Yemen", []string{"Republic of Yemen", "YEMENI", "也门"}},
+	{"YT", "MYT", "Mayotte", []string{"马约特"}},
+	{"ZA", "ZAF", "South Africa", []string{"Republic of South Africa", "SOUTH AFRICAN", "南非"}},
+	{"ZM", "ZMB", "Zambia", []string{"Republic of Zambia", "ZAMBIAN", "赞比亚"}},
+	{"ZW", "ZWE", "Zimbabwe", []string{"Republic of Zimbabwe", "ZIMBABWEAN", "津巴布韦"}},
+}
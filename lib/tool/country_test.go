@@ -0,0 +1,66 @@
+package tool
+
+import "testing"
+
+func TestLookupCountry(t *testing.T) {
+	tests := []struct {
+		value, code, alpha2, icao string
+	}{
+		{"CHN", "CHN", "CN", "CHN"},
+		{"cn", "CHN", "CN", "CHN"},
+		{"China", "CHN", "CN", "CHN"},
+		{"CHINESE", "CHN", "CN", "CHN"},
+		{"People's Republic of China", "CHN", "CN", "CHN"},
+		{"中华人民共和国", "CHN", "CN", "CHN"},
+		{"The Netherlands", "NLD", "NL", "NLD"},
+		{"Korea, Republic of", "KOR", "KR", "KOR"},
+		{"republic-of-korea", "KOR", "KR", "KOR"},
+		{"CÔTE D'IVOIRE", "CIV", "CI", "CIV"},
+		{"UK", "GBR", "GB", "GBR"},
+		{"United States of America", "USA", "US", "USA"},
+		// 德国在机读区中使用 D
+		{"D", "DEU", "DE", "D"},
+		{"DEU", "DEU", "DE", "D"},
+		{"GERMAN", "DEU", "DE", "D"},
+		// 代码优先于名称, GB 不会被 ICAO 的英国属地公民等条目覆盖
+		{"GB", "GBR", "GB", "GBR"},
+		{"GBD", "GBR", "GB", "GBD"},
+		// 只有 ICAO 代码的条目
+		{"XXA", "XXA", "", "XXA"},
+		{"stateless", "XXA", "", "XXA"},
+		{"无国籍", "XXA", "", "XXA"},
+		{"RKS", "RKS", "", "RKS"},
+		{"UNO", "UNO", "", "UNO"},
+		{"United Nations", "UNO", "", "UNO"},
+		{"UTO", "UTO", "", "UTO"},
+	}
+	for _, tt := range tests {
+		country, err := LookupCountry(tt.value)
+		if err != nil {
+			t.Errorf("LookupCountry(%q) error = %v", tt.value, err)
+			continue
+		}
+		if country.Code() != tt.code || country.Alpha2 != tt.alpha2 || country.ICAO != tt.icao {
+			t.Errorf("LookupCountry(%q) = %s/%s/%s, want %s/%s/%s", tt.value, country.Code(), country.Alpha2, country.ICAO, tt.code, tt.alpha2, tt.icao)
+		}
+	}
+}
+
+func TestLookupCountryUnknown(t *testing.T) {
+	for _, value := range []string{"", " - ", "ZZZ", "Atlantis", "C"} {
+		if country, err := LookupCountry(value); err == nil {
+			t.Errorf("LookupCountry(%q) = %s, want error", value, country.Code())
+		}
+	}
+}
+
+// TestCountryIndex 每个 ISO 代码都能查到自己, 名称和别名不会覆盖代码
+func TestCountryIndex(t *testing.T) {
+	for _, entry := range countries {
+		for _, code := range []string{entry.alpha2, entry.alpha3} {
+			if country, err := LookupCountry(code); err != nil || country.Alpha3 != entry.alpha3 {
+				t.Errorf("LookupCountry(%q) = %v, %v, want %s", code, country, err, entry.alpha3)
+			}
+		}
+	}
+}
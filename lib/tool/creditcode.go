@@ -0,0 +1,32 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	creditCodeChars   = "0123456789ABCDEFGHJKLMNPQRTUWXY"
+	creditCodeWeights = []int{1, 3, 9, 27, 19, 26, 16, 17, 20, 29, 25, 13, 8, 24, 10, 30, 28}
+)
+
+// ValidateCreditCode 按 GB 32100 校验 18 位统一社会信用代码的字符集和校验码
+func ValidateCreditCode(creditCode string) error {
+	creditCode = strings.ToUpper(strings.TrimSpace(creditCode))
+	if len(creditCode) != 18 {
+		return fmt.Errorf("credit code must have 18 characters, got %d", len(creditCode))
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		value := strings.IndexByte(creditCodeChars, creditCode[i])
+		if value == -1 {
+			return fmt.Errorf("credit code has invalid character %c at position %d", creditCode[i], i+1)
+		}
+		sum += value * creditCodeWeights[i]
+	}
+	checkCode := creditCodeChars[(31-sum%31)%31]
+	if creditCode[17] != checkCode {
+		return fmt.Errorf("credit code check character mismatch, expect %c got %c", checkCode, creditCode[17])
+	}
+	return nil
+}
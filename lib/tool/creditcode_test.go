@@ -0,0 +1,29 @@
+package tool
+
+import "testing"
+
+func TestValidateCreditCode(t *testing.T) {
+	tests := []struct {
+		creditCode string
+		valid      bool
+	}{
+		// GB 32100 的示例
+		{"91350100M000100Y43", true},
+		{"91110000802100433B", true},
+		{" 91110000802100433b ", true},
+		{"91440300MA5FXXXX1Y", true},
+		{"91350100M000100Y44", false},
+		{"91110000802100433A", false},
+		{"91350100M000100Y4", false},
+		{"91350100M000100Y433", false},
+		// GB 32100 不使用 I, O, S, V, Z
+		{"91350100M000I00Y43", false},
+		{"91350100M000O00Y43", false},
+		{"9135010-M000100Y43", false},
+	}
+	for _, tt := range tests {
+		if err := ValidateCreditCode(tt.creditCode); (err == nil) != tt.valid {
+			t.Errorf("ValidateCreditCode(%q) error = %v, want valid %v", tt.creditCode, err, tt.valid)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DateKind 日期字段的语义, 用于确定两位年份的世纪
+type DateKind string
+
+const (
+	DateAny    DateKind = ""
+	DateBirth  DateKind = "birth"  // 不晚于今天
+	DateIssue  DateKind = "issue"  // 不晚于今天
+	DateExpiry DateKind = "expiry" // 通常在今天之后的几十年内
+)
+
+// DateContext 解析日期时的上下文
+type DateContext struct {
+	Kind    DateKind
+	Country string    // 签发国, 用于判断纯数字日期是日/月还是月/日, 支持 LookupCountry 能识别的任意写法
+	Now     time.Time // 为零值时使用当前时间
+}
+
+// ParsedDate 解析结果, Ambiguous 为 true 时 Reason 说明按哪种方式理解了日期
+type ParsedDate struct {
+	Time      time.Time
+	Ambiguous bool
+	Reason    string
+}
+
+// monthNames 各语言的月份全称, 去掉了重音符号; 输入的月份可以是全称或至少 3 个字母的前缀
+var monthNames = [][]string{
+	{"JANUARY", "JANVIER", "JANUAR", "JANNER", "ENERO", "GENNAIO", "JANEIRO", "JANUARI", "ЯНВАРЬ", "ЯНВАРЯ"},
+	{"FEBRUARY", "FEVRIER", "FEBRUAR", "FEBRERO", "FEBBRAIO", "FEVEREIRO", "FEBRUARI", "ФЕВРАЛЬ", "ФЕВРАЛЯ"},
+	{"MARCH", "MARS", "MARZ", "MRZ", "MARZO", "MARCO", "MAART", "MRT", "МАРТ", "МАРТА"},
+	{"APRIL", "AVRIL", "ABRIL", "APRILE", "АПРЕЛЬ", "АПРЕЛЯ"},
+	{"MAY", "MAI", "MAYO", "MAGGIO", "MAIO", "MEI", "МАЙ", "МАЯ"},
+	{"JUNE", "JUIN", "JUNI", "JUNIO", "GIUGNO", "JUNHO", "ИЮНЬ", "ИЮНЯ"},
+	{"JULY", "JUILLET", "JULI", "JULIO", "LUGLIO", "JULHO", "ИЮЛЬ", "ИЮЛЯ"},
+	{"AUGUST", "AOUT", "AGOSTO", "AUGUSTUS", "АВГУСТ", "АВГУСТА"},
+	{"SEPTEMBER", "SEPTEMBRE", "SEPTIEMBRE", "SETIEMBRE", "SETTEMBRE", "SETEMBRO", "СЕНТЯБРЬ", "СЕНТЯБРЯ"},
+	{"OCTOBER", "OCTOBRE", "OKTOBER", "OCTUBRE", "OTTOBRE", "OUTUBRO", "ОКТЯБРЬ", "ОКТЯБРЯ"},
+	{"NOVEMBER", "NOVEMBRE", "NOVIEMBRE", "NOVEMBRO", "НОЯБРЬ", "НОЯБРЯ"},
+	{"DECEMBER", "DECEMBRE", "DEZEMBER", "DICIEMBRE", "DICEMBRE", "DEZEMBRO", "ДЕКАБРЬ", "ДЕКАБРЯ"},
+}
+
+// monthFirstCountries 纯数字日期习惯写作 月/日/年 的国家
+var monthFirstCountries = map[string]bool{"USA": true, "FSM": true, "PLW": true, "MHL": true, "PHL": true}
+
+var (
+	accentReplacer = strings.NewReplacer("É", "E", "È", "E", "Ê", "E", "Ë", "E", "À", "A", "Â", "A", "Ä", "A", "Á", "A",
+		"Û", "U", "Ù", "U", "Ü", "U", "Ú", "U", "Ô", "O", "Ö", "O", "Ó", "O", "Î", "I", "Ï", "I", "Í", "I", "Ç", "C", "Ñ", "N")
+	// 中文、日文、韩文日期中的年月日
+	cjkDateReplacer  = strings.NewReplacer("年", " ", "月", " ", "日", " ", "년", " ", "월", " ", "일", " ")
+	dateTokenPattern = regexp.MustCompile(`\d+|\p{L}+`)
+)
+
+// ParseDate 解析各种写法的日期, 例如 "08 JUIN 1996", "1996年6月8日", "08/06/96", "08 JUN/JUIN 96", "June 8, 1996"
+// 日和月都不大于 12 时按签发国的习惯理解并标记为 Ambiguous; 两位年份按 DateContext.Kind 确定世纪
+func ParseDate(value string, dctx DateContext) (*ParsedDate, error) {
+	now := dctx.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	text := accentReplacer.Replace(strings.ToUpper(cjkDateReplacer.Replace(strings.TrimSpace(value))))
+	if text == "" {
+		return nil, errors.New("date is empty")
+	}
+
+	var numbers []string
+	month, monthIndex := 0, -1
+	for _, token := range dateTokenPattern.FindAllString(text, -1) {
+		if unicode.IsDigit([]rune(token)[0]) {
+			numbers = append(numbers, token)
+			continue
+		}
+		m := lookupMonth(token)
+		if m == 0 {
+			continue // DE, OF 等连接词
+		}
+		if month != 0 && m != month {
+			return nil, fmt.Errorf("date %q has conflicting month names", value)
+		}
+		if month == 0 {
+			month, monthIndex = m, len(numbers)
+		}
+	}
+
+	// 中国护照等写作 "08 6月/JUN 1996", 月份名称前与之相同的数字也是月份
+	if month != 0 && len(numbers) == 3 && monthIndex > 0 && atoi(numbers[monthIndex-1]) == month {
+		numbers = append(numbers[:monthIndex-1], numbers[monthIndex:]...)
+		monthIndex--
+	}
+
+	result := &ParsedDate{}
+	var day, year int
+	var yearText string
+	switch {
+	case month != 0 && len(numbers) == 2:
+		// 有月份名称时剩下的两个数是日和年: 4 位或大于 31 的是年, 否则月份之前的数是日
+		first, second := numbers[0], numbers[1]
+		switch {
+		case len(first) == 4 || atoi(first) > 31:
+			yearText, day = first, atoi(second)
+		case len(second) == 4 || atoi(second) > 31 || monthIndex >= 1:
+			day, yearText = atoi(first), second
+		default:
+			yearText, day = first, atoi(second)
+		}
+	case month == 0 && len(numbers) == 1 && len(numbers[0]) == 8:
+		// 19960608 或 08061996
+		if t, err := time.Parse("20060102", numbers[0]); err == nil {
+			return &ParsedDate{Time: t}, nil
+		}
+		numbers = []string{numbers[0][0:2], numbers[0][2:4], numbers[0][4:8]}
+		fallthrough
+	case month == 0 && len(numbers) == 3:
+		a, b, c := numbers[0], numbers[1], numbers[2]
+		if len(a) == 4 || atoi(a) > 31 {
+			yearText, month, day = a, atoi(b), atoi(c)
+			break
+		}
+		yearText = c
+		x, y := atoi(a), atoi(b)
+		monthFirst := false
+		if country, err := LookupCountry(dctx.Country); err == nil {
+			monthFirst = monthFirstCountries[country.Code()]
+		}
+		switch {
+		case x > 12:
+			day, month = x, y
+		case y > 12:
+			month, day = x, y
+		case monthFirst:
+			month, day = x, y
+		default:
+			day, month = x, y
+		}
+		if x <= 12 && y <= 12 && x != y {
+			result.Ambiguous = true
+			result.Reason = "day and month order is ambiguous, read as dd/mm"
+			if monthFirst {
+				result.Reason = "day and month order is ambiguous, read as mm/dd"
+			}
+		}
+	default:
+		return nil, fmt.Errorf("date %q is not recognized", value)
+	}
+
+	year = atoi(yearText)
+	if len(yearText) <= 2 {
+		year = twoDigitYear(year, dctx.Kind, now)
+		if dctx.Kind == DateAny {
+			result.Ambiguous = true
+			result.Reason = strings.TrimPrefix(result.Reason+"; two-digit year read as "+strconv.Itoa(year), "; ")
+		}
+	} else if len(yearText) != 4 {
+		return nil, fmt.Errorf("date %q has invalid year", value)
+	}
+
+	t := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if t.Year() != year || int(t.Month()) != month || t.Day() != day {
+		return nil, fmt.Errorf("date %q is not a valid calendar date", value)
+	}
+	result.Time = t
+	return result, nil
+}
+
+// lookupMonth 返回月份名称或其前缀对应的月份, 不能唯一确定时返回 0
+func lookupMonth(token string) int {
+	if len([]rune(token)) < 3 {
+		return 0
+	}
+	found := 0
+	for i, names := range monthNames {
+		for _, name := range names {
+			if strings.HasPrefix(name, token) {
+				if found != 0 && found != i+1 {
+					return 0
+				}
+				found = i + 1
+				break
+			}
+		}
+	}
+	return found
+}
+
+// twoDigitYear 出生和签发日期不晚于今年, 有效期在今年之后 50 年内, 其他日期在今年之后 20 年内
+func twoDigitYear(year int, kind DateKind, now time.Time) int {
+	year += now.Year() / 100 * 100
+	limit := now.Year() + 20
+	switch kind {
+	case DateBirth, DateIssue:
+		limit = now.Year()
+	case DateExpiry:
+		limit = now.Year() + 50
+	}
+	if year > limit {
+		year -= 100
+	}
+	return year
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
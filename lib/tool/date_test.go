@@ -0,0 +1,74 @@
+package tool
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value     string
+		dctx      DateContext
+		want      string
+		ambiguous bool
+	}{
+		{"08 JUIN 1996", DateContext{}, "1996-06-08", false},
+		{"1996年6月8日", DateContext{}, "1996-06-08", false},
+		{"08/06/96", DateContext{Kind: DateBirth}, "1996-06-08", true},
+		{"08 JUN/JUIN 96", DateContext{Kind: DateBirth}, "1996-06-08", false},
+		{"08 6月/JUN 1996", DateContext{}, "1996-06-08", false},
+		{"June 8, 1996", DateContext{}, "1996-06-08", false},
+		{"8 DE JUNIO DE 1996", DateContext{}, "1996-06-08", false},
+		{"08 ИЮНЯ 1996", DateContext{}, "1996-06-08", false},
+		{"1996.06.08", DateContext{}, "1996-06-08", false},
+		{"19960608", DateContext{}, "1996-06-08", false},
+		{"08061996", DateContext{}, "1996-06-08", true},
+		{"1996년 6월 8일", DateContext{}, "1996-06-08", false},
+
+		// 日和月都不大于 12 时按签发国习惯理解
+		{"06/08/1996", DateContext{Country: "FRA"}, "1996-08-06", true},
+		{"06/08/1996", DateContext{Country: "USA"}, "1996-06-08", true},
+		{"06/08/1996", DateContext{Country: "United States"}, "1996-06-08", true},
+		{"25/06/1996", DateContext{Country: "USA"}, "1996-06-25", false},
+		{"06/25/1996", DateContext{Country: "FRA"}, "1996-06-25", false},
+		{"06/06/1996", DateContext{Country: "USA"}, "1996-06-06", false},
+
+		// 两位年份按日期类型确定世纪
+		{"08/06/26", DateContext{Kind: DateBirth, Country: "FRA"}, "2026-06-08", true},
+		{"08/06/27", DateContext{Kind: DateBirth, Country: "FRA"}, "1927-06-08", true},
+		{"08 JUN 40", DateContext{Kind: DateExpiry}, "2040-06-08", false},
+		{"08 JUN 76", DateContext{Kind: DateExpiry}, "2076-06-08", false},
+		{"08 JUN 80", DateContext{Kind: DateExpiry}, "1980-06-08", false},
+		{"08 JUN 80", DateContext{Kind: DateIssue}, "1980-06-08", false},
+		{"08 JUN 40", DateContext{}, "2040-06-08", true},
+		{"08 JUN 50", DateContext{}, "1950-06-08", true},
+	}
+	for _, tt := range tests {
+		tt.dctx.Now = now
+		got, err := ParseDate(tt.value, tt.dctx)
+		if err != nil {
+			t.Errorf("ParseDate(%q, %+v) error = %v", tt.value, tt.dctx, err)
+			continue
+		}
+		if got.Time.Format("2006-01-02") != tt.want || got.Ambiguous != tt.ambiguous {
+			t.Errorf("ParseDate(%q, %+v) = %s ambiguous %v, want %s ambiguous %v",
+				tt.value, tt.dctx, got.Time.Format("2006-01-02"), got.Ambiguous, tt.want, tt.ambiguous)
+		}
+	}
+}
+
+func TestParseDateInvalid(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"JUIN",
+		"31/02/1996",
+		"08 JUN JUL 1996",
+		"08/06/199",
+		"1996/13/45",
+	} {
+		if got, err := ParseDate(value, DateContext{}); err == nil {
+			t.Errorf("ParseDate(%q) = %s, want error", value, got.Time.Format("2006-01-02"))
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 模型输出异常的类别
+const (
+	OutputBlocked   = "blocked"   // 请求或输出被安全策略拦截
+	OutputFiltered  = "filtered"  // 输出被内容过滤
+	OutputTruncated = "truncated" // 输出达到长度上限被截断
+	OutputEmpty     = "empty"     // 没有返回任何内容
+)
+
+// OutputError 模型没有给出可用输出时返回的结构化错误
+type OutputError struct {
+	Platform     string `json:"platform"`
+	Kind         string `json:"kind"`
+	FinishReason string `json:"finish_reason"`
+}
+
+func (e *OutputError) Error() string {
+	if e.FinishReason == "" {
+		return fmt.Sprintf("%s: model output %s", e.Platform, e.Kind)
+	}
+	return fmt.Sprintf("%s: model output %s (finish_reason: %s)", e.Platform, e.Kind, e.FinishReason)
+}
+
+// CheckFinishReason 根据 OpenAI 兼容接口的 finish_reason 和内容判断输出是否可用
+func CheckFinishReason(platform, finishReason, content string) error {
+	switch strings.ToLower(finishReason) {
+	case "length", "max_tokens":
+		return &OutputError{Platform: platform, Kind: OutputTruncated, FinishReason: finishReason}
+	case "content_filter", "sensitive":
+		return &OutputError{Platform: platform, Kind: OutputFiltered, FinishReason: finishReason}
+	case "safety", "blocked", "prohibited_content":
+		return &OutputError{Platform: platform, Kind: OutputBlocked, FinishReason: finishReason}
+	}
+	if strings.TrimSpace(content) == "" {
+		return &OutputError{Platform: platform, Kind: OutputEmpty, FinishReason: finishReason}
+	}
+	return nil
+}
@@ -5,7 +5,6 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
-	"time"
 )
 
 func ExtractNumbers(input string) []string {
@@ -16,36 +15,23 @@ func ExtractNumbers(input string) []string {
 	return numbers
 }
 
-// parseAndFormatDate 尝试将多种日期格式转换为 dd/mm/yyyy 格式
+// ParseAndFormatDate 将各种写法的日期转换为 outputFormat 格式, 不关心日期语义和签发国, 需要时使用 ParseDate
 func ParseAndFormatDate(inputDate string, outputFormat string) (string, error) {
-	// 定义多种可能的输入日期格式
-	formats := []string{
-		"02 Jan 2006",     // 08 JUN 1996
-		"02/01/2006",      // 08/06/1996
-		"2006-01-02",      // 1996-06-08
-		"02-01-2006",      // 08-06-1996
-		"02.01.2006",      // 08.06.1996
-		"January 2, 2006", // June 8, 1996
-		"2 Jan 2006",      // 8 JUN 1996
-	}
-
-	// 遍历格式列表并尝试解析
-	var parsedTime time.Time
-	var err error
-	for _, format := range formats {
-		parsedTime, err = time.Parse(format, strings.ToUpper(inputDate))
-		if err == nil {
-			// 成功解析，退出循环
-			break
-		}
-	}
-
+	parsed, err := ParseDate(inputDate, DateContext{})
 	if err != nil {
 		return "", fmt.Errorf("无法解析日期: %s", inputDate)
 	}
+	return parsed.Time.Format(outputFormat), nil
+}
 
-	// 格式化为目标格式 dd/mm/yyyy
-	return parsedTime.Format(outputFormat), nil
+// ExtractJSON 截取文本中第一个 { 到最后一个 } 之间的内容
+func ExtractJSON(content string) string {
+	startIdx := strings.Index(content, "{")
+	endIdx := strings.LastIndex(content, "}")
+	if startIdx != -1 && endIdx != -1 && endIdx > startIdx {
+		return content[startIdx : endIdx+1]
+	}
+	return content
 }
 
 func GetFuncInfo() string {
@@ -0,0 +1,89 @@
+package tool
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	idNumberWeights    = []int{7, 9, 10, 5, 8, 4, 2, 1, 6, 3, 7, 9, 10, 5, 8, 4, 2}
+	idNumberCheckCodes = "10X98765432"
+)
+
+// ValidateIdNumber 按 GB 11643 校验 18 位公民身份号码的格式、出生日期和校验码
+func ValidateIdNumber(idNumber string) error {
+	idNumber = strings.ToUpper(strings.TrimSpace(idNumber))
+	if len(idNumber) != 18 {
+		return fmt.Errorf("id number must have 18 characters, got %d", len(idNumber))
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		if idNumber[i] < '0' || idNumber[i] > '9' {
+			return fmt.Errorf("id number has non-digit character at position %d", i+1)
+		}
+		sum += int(idNumber[i]-'0') * idNumberWeights[i]
+	}
+	if checkCode := idNumberCheckCodes[sum%11]; idNumber[17] != checkCode {
+		return fmt.Errorf("id number check code mismatch, expect %c got %c", checkCode, idNumber[17])
+	}
+	if _, err := time.Parse("20060102", idNumber[6:14]); err != nil {
+		return fmt.Errorf("id number has invalid birth date %s", idNumber[6:14])
+	}
+	return nil
+}
+
+// CheckIdCard 校验身份证号码, 并与识别出的出生日期、性别交叉比对, 返回发现的问题
+func CheckIdCard(idNumber, birthDate, sex string) (warnings []string) {
+	if err := ValidateIdNumber(idNumber); err != nil {
+		return []string{err.Error()}
+	}
+	idNumber = strings.ToUpper(strings.TrimSpace(idNumber))
+	if birthDate != "" {
+		if converted, err := ParseAndFormatDate(birthDate, "20060102"); err != nil {
+			warnings = append(warnings, fmt.Sprintf("birth date %s is not parsable", birthDate))
+		} else if converted != idNumber[6:14] {
+			warnings = append(warnings, fmt.Sprintf("birth date %s does not match id number", birthDate))
+		}
+	}
+	if sex != "" {
+		male := (idNumber[16]-'0')%2 == 1
+		switch strings.ToUpper(strings.TrimSpace(sex)) {
+		case "男", "M", "MALE":
+			if !male {
+				warnings = append(warnings, fmt.Sprintf("sex %s does not match id number", sex))
+			}
+		case "女", "F", "FEMALE":
+			if male {
+				warnings = append(warnings, fmt.Sprintf("sex %s does not match id number", sex))
+			}
+		default:
+			warnings = append(warnings, fmt.Sprintf("sex %s is not recognized", sex))
+		}
+	}
+	return warnings
+}
+
+// FormatDateRange 将 "开始-结束" 形式的有效期限中的日期转换为 outputFormat, "长期" 等无法解析的部分保持不变
+func FormatDateRange(period, outputFormat string) string {
+	parts := strings.Split(period, "-")
+	switch len(parts) {
+	case 2:
+	case 4:
+		// 日期本身使用 - 分隔, 即 yyyy-mm-dd-长期
+		parts = []string{strings.Join(parts[:3], "-"), parts[3]}
+	case 6:
+		// 日期本身使用 - 分隔, 即 yyyy-mm-dd-yyyy-mm-dd
+		parts = []string{strings.Join(parts[:3], "-"), strings.Join(parts[3:], "-")}
+	default:
+		return period
+	}
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if converted, err := ParseAndFormatDate(part, outputFormat); err == nil {
+			part = converted
+		}
+		parts[i] = part
+	}
+	return strings.Join(parts, "-")
+}
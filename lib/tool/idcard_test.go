@@ -0,0 +1,54 @@
+package tool
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidateIdNumber(t *testing.T) {
+	tests := []struct {
+		idNumber string
+		valid    bool
+	}{
+		// GB 11643 的示例
+		{"11010519491231002X", true},
+		{"11010519491231002x", true},
+		{" 440304199606081234 ", true},
+		{"110101200002290018", true},
+		{"110101199003070011", true},
+		{"110101199003070012", false},
+		{"11010519491231002", false},
+		{"1101051949123100200", false},
+		{"11010519491231A02X", false},
+		// 校验码正确但出生日期不存在
+		{"110101199902290018", false},
+	}
+	for _, tt := range tests {
+		if err := ValidateIdNumber(tt.idNumber); (err == nil) != tt.valid {
+			t.Errorf("ValidateIdNumber(%q) error = %v, want valid %v", tt.idNumber, err, tt.valid)
+		}
+	}
+}
+
+func TestCheckIdCard(t *testing.T) {
+	tests := []struct {
+		idNumber, birthDate, sex string
+		want                     []string
+	}{
+		{"440304199606081234", "1996年6月8日", "男", nil},
+		{"440304199606081234", "1996.06.08", "M", nil},
+		{"11010519491231002X", "1949-12-31", "女", nil},
+		{"440304199606081234", "", "", nil},
+		{"440304199606081234", "1996年6月9日", "女", []string{
+			"birth date 1996年6月9日 does not match id number",
+			"sex 女 does not match id number",
+		}},
+		{"440304199606081234", "", "未知", []string{"sex 未知 is not recognized"}},
+		{"440304199606081235", "1996年6月8日", "男", []string{"id number check code mismatch, expect 4 got 5"}},
+	}
+	for _, tt := range tests {
+		if got := CheckIdCard(tt.idNumber, tt.birthDate, tt.sex); !slices.Equal(got, tt.want) {
+			t.Errorf("CheckIdCard(%q, %q, %q) = %q, want %q", tt.idNumber, tt.birthDate, tt.sex, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package tool
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var httpLinkPattern = regexp.MustCompile(`^https?://[^\s/$.?#].[^\s]*$`)
+
+var (
+	// MaxImageSize 从链接读取图片的最大字节数
+	MaxImageSize int64 = 20 << 20
+	imageClient        = &http.Client{Timeout: 30 * time.Second}
+)
+
+// IsHTTPLink 判断字符串是否是 HTTP/HTTPS 链接
+func IsHTTPLink(s string) bool {
+	return httpLinkPattern.MatchString(s)
+}
+
+// Base64ToBytes 解码 base64 图片, 支持 data:image/png;base64, 前缀
+func Base64ToBytes(base64Data string) ([]byte, error) {
+	if idx := strings.Index(base64Data, ","); idx != -1 {
+		base64Data = base64Data[idx+1:]
+	}
+
+	data, err := base64.StdEncoding.DecodeString(base64Data)
+	if err != nil {
+		return nil, fmt.Errorf("Base64 decode error: %v", err)
+	}
+
+	return data, nil
+}
+
+// LoadImage 读取 HTTP 链接或 base64 字符串中的图片内容
+func LoadImage(ctx context.Context, image string) ([]byte, error) {
+	if !IsHTTPLink(image) {
+		return Base64ToBytes(image)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, image, nil)
+	if err != nil {
+		return nil, err
+	}
+	imageResp, err := imageClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer imageResp.Body.Close()
+	if imageResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch image: %s", imageResp.Status)
+	}
+	// 多读一个字节用于判断图片是否超过大小限制
+	imageBytes, err := io.ReadAll(io.LimitReader(imageResp.Body, MaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(imageBytes)) > MaxImageSize {
+		return nil, fmt.Errorf("image is larger than %d bytes", MaxImageSize)
+	}
+	return imageBytes, nil
+}
+
+// ImageSize 返回图片的宽高, 支持 jpeg, png, gif 和 webp
+func ImageSize(imageBytes []byte) (width, height int, err error) {
+	if config, _, err := image.DecodeConfig(bytes.NewReader(imageBytes)); err == nil {
+		return config.Width, config.Height, nil
+	}
+	return webpSize(imageBytes)
+}
+
+// webpSize 从 RIFF 头中读取 webp 图片的宽高, 标准库不支持解码 webp
+func webpSize(data []byte) (width, height int, err error) {
+	if len(data) < 30 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 0, 0, errors.New("unsupported image format")
+	}
+	chunk := data[20:]
+	switch string(data[12:16]) {
+	case "VP8 ":
+		// 关键帧起始码之后是 14 位宽和 14 位高
+		if chunk[3] != 0x9d || chunk[4] != 0x01 || chunk[5] != 0x2a {
+			return 0, 0, errors.New("invalid webp VP8 frame")
+		}
+		return int(binary.LittleEndian.Uint16(chunk[6:8]) & 0x3fff), int(binary.LittleEndian.Uint16(chunk[8:10]) & 0x3fff), nil
+	case "VP8L":
+		if chunk[0] != 0x2f {
+			return 0, 0, errors.New("invalid webp VP8L header")
+		}
+		bits := binary.LittleEndian.Uint32(chunk[1:5])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1, nil
+	case "VP8X":
+		width = int(chunk[4]) | int(chunk[5])<<8 | int(chunk[6])<<16
+		height = int(chunk[7]) | int(chunk[8])<<8 | int(chunk[9])<<16
+		return width + 1, height + 1, nil
+	}
+	return 0, 0, errors.New("unsupported webp format")
+}
@@ -0,0 +1,65 @@
+package tool
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	codeFencePattern      = regexp.MustCompile("(?s)^```[a-zA-Z]*\\s*\\n(.*?)\\n?```$")
+	headingPattern        = regexp.MustCompile(`^#{1,6}\s+`)
+	quotePattern          = regexp.MustCompile(`^>\s?`)
+	tableSeparatorPattern = regexp.MustCompile(`^\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?$`)
+	imagePattern          = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkPattern           = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	// 依次匹配 **粗体** __粗体__ ~~删除线~~ `代码` *斜体*
+	emphasisPatterns = []*regexp.Regexp{
+		regexp.MustCompile(`\*\*(.+?)\*\*`),
+		regexp.MustCompile(`__(.+?)__`),
+		regexp.MustCompile(`~~(.+?)~~`),
+		regexp.MustCompile("`([^`]+)`"),
+		regexp.MustCompile(`\*(\S(?:[^*]*\S)?)\*`),
+	}
+)
+
+// StripCodeFence 去掉模型常在整段输出外包裹的 ```markdown ... ``` 代码块标记
+func StripCodeFence(content string) string {
+	content = strings.TrimSpace(content)
+	if match := codeFencePattern.FindStringSubmatch(content); match != nil {
+		return match[1]
+	}
+	return content
+}
+
+// MarkdownToText 将 Markdown 转换为纯文本: 去掉标题、引用和强调标记, 链接只保留文字,
+// 表格每行的单元格以制表符分隔, 列表符号和段落换行保持不变
+func MarkdownToText(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	result := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.Contains(trimmed, "|") && tableSeparatorPattern.MatchString(trimmed) {
+			continue
+		}
+		if trimmed == "---" || trimmed == "***" {
+			result = append(result, "")
+			continue
+		}
+		trimmed = headingPattern.ReplaceAllString(trimmed, "")
+		trimmed = quotePattern.ReplaceAllString(trimmed, "")
+		if strings.HasPrefix(trimmed, "|") && strings.HasSuffix(trimmed, "|") && len(trimmed) > 1 {
+			cells := strings.Split(trimmed[1:len(trimmed)-1], "|")
+			for i, cell := range cells {
+				cells[i] = strings.TrimSpace(cell)
+			}
+			trimmed = strings.Join(cells, "\t")
+		}
+		trimmed = imagePattern.ReplaceAllString(trimmed, "$1")
+		trimmed = linkPattern.ReplaceAllString(trimmed, "$1")
+		for _, pattern := range emphasisPatterns {
+			trimmed = pattern.ReplaceAllString(trimmed, "$1")
+		}
+		result = append(result, trimmed)
+	}
+	return strings.TrimSpace(strings.Join(result, "\n"))
+}
@@ -0,0 +1,40 @@
+package tool
+
+import "testing"
+
+func TestStripCodeFence(t *testing.T) {
+	tests := []struct {
+		content, want string
+	}{
+		{"```markdown\n# Title\ntext\n```", "# Title\ntext"},
+		{"  ```\nplain\n```  ", "plain"},
+		{"# Title\n```go\ncode\n```", "# Title\n```go\ncode\n```"},
+		{"no fence", "no fence"},
+	}
+	for _, tt := range tests {
+		if got := StripCodeFence(tt.content); got != tt.want {
+			t.Errorf("StripCodeFence(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestMarkdownToText(t *testing.T) {
+	tests := []struct {
+		name, markdown, want string
+	}{
+		{"heading and quote", "# 标题\n\n> 引用的文字", "标题\n\n引用的文字"},
+		{"emphasis", "**粗体** __粗体__ ~~删除~~ `code` *斜体* 2 * 3 * 4", "粗体 粗体 删除 code 斜体 2 * 3 * 4"},
+		{"links and images", "见 [官网](https://example.com) ![图标](a.png)", "见 官网 图标"},
+		{"lists are kept", "- 第一项\n- 第二项\n1. 编号", "- 第一项\n- 第二项\n1. 编号"},
+		{"table", "| 名称 | 数量 |\n| --- | :---: |\n| 苹果 | 2 |", "名称\t数量\n苹果\t2"},
+		{"horizontal rule", "上\n---\n下", "上\n\n下"},
+		{"crlf", "第一行\r\n第二行\r\n", "第一行\n第二行"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToText(tt.markdown); got != tt.want {
+				t.Errorf("MarkdownToText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
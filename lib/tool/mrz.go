@@ -0,0 +1,281 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// MRZ 按 ICAO 9303 解析的机读区
+type MRZ struct {
+	Format         string          `json:"format"` // TD1, TD2, TD3
+	Lines          []string        `json:"lines"`
+	DocumentCode   string          `json:"document_code"`
+	IssuingState   string          `json:"issuing_state"`
+	Surname        string          `json:"surname"`
+	GivenNames     string          `json:"given_names"`
+	DocumentNumber string          `json:"document_number"`
+	Nationality    string          `json:"nationality"`
+	BirthDate      string          `json:"birth_date"` // YYMMDD
+	Sex            string          `json:"sex"`        // M, F 或 X
+	ExpiryDate     string          `json:"expiry_date"`
+	OptionalData   string          `json:"optional_data"`
+	Checks         map[string]bool `json:"checks"` // 各校验位是否通过: document_number, birth_date, expiry_date, optional_data (仅 TD3), composite
+	// Candidates 校验失败时, 替换形近字符后能通过校验的值, 以校验位名称为键; 只是猜测, 不计入校验结果, Lines 保持原样
+	Candidates map[string]string `json:"candidates,omitempty"`
+}
+
+var (
+	mrzWeights = []int{7, 3, 1}
+	// mrzConfusions 模型容易混淆的字符, 文档号校验失败时尝试互换以给出候选
+	mrzConfusions = map[byte]byte{'O': '0', '0': 'O', 'I': '1', '1': 'I', 'B': '8', '8': 'B', 'S': '5', '5': 'S', 'Z': '2', '2': 'Z'}
+	// mrzDigitFixes 只能是数字的位置上常见的误识别, 用于给出候选值
+	mrzDigitFixes = strings.NewReplacer("O", "0", "D", "0", "Q", "0", "I", "1", "L", "1", "Z", "2", "S", "5", "G", "6", "B", "8")
+)
+
+// MRZCheckDigit 计算 ICAO 9303 校验位: 数字取本身, A-Z 为 10-35, < 为 0, 按 7, 3, 1 加权求和后对 10 取余
+func MRZCheckDigit(value string) byte {
+	sum := 0
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		var v int
+		switch {
+		case c >= '0' && c <= '9':
+			v = int(c - '0')
+		case c >= 'A' && c <= 'Z':
+			v = int(c-'A') + 10
+		}
+		sum += v * mrzWeights[i%3]
+	}
+	return byte('0' + sum%10)
+}
+
+// ParseMRZ 解析 2 行 (TD2, TD3) 或 3 行 (TD1) 机读区, 自动去除空白并把缺少的填充符补齐
+// 行数或长度不符合任何格式时返回错误, 校验位的结果记录在 Checks 中
+func ParseMRZ(text string) (*MRZ, error) {
+	var lines []string
+	for _, line := range strings.Split(strings.ToUpper(text), "\n") {
+		line = strings.Map(func(r rune) rune {
+			switch {
+			case r == ' ' || r == '\t' || r == '\r':
+				return -1
+			case r == '«' || r == '‹' || r == '＜':
+				return '<'
+			}
+			return r
+		}, line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	var length int
+	m := &MRZ{Checks: map[string]bool{}, Candidates: map[string]string{}}
+	switch {
+	case len(lines) == 3:
+		m.Format, length = "TD1", 30
+	case len(lines) == 2 && max(len(lines[0]), len(lines[1])) > 38:
+		m.Format, length = "TD3", 44
+	case len(lines) == 2:
+		m.Format, length = "TD2", 36
+	default:
+		return nil, fmt.Errorf("mrz must have 2 or 3 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if len(line) > length {
+			return nil, fmt.Errorf("mrz line %d has %d characters, %s lines have %d", i+1, len(line), m.Format, length)
+		}
+		for j := 0; j < len(line); j++ {
+			if c := line[j]; c != '<' && (c < '0' || c > '9') && (c < 'A' || c > 'Z') {
+				return nil, fmt.Errorf("mrz line %d has invalid character %q", i+1, c)
+			}
+		}
+		lines[i] = line + strings.Repeat("<", length-len(line))
+	}
+	m.Lines = lines
+
+	if m.Format == "TD1" {
+		m.parseTD1()
+	} else {
+		m.parseTD23()
+	}
+	return m, nil
+}
+
+// parseTD23 TD3 (护照) 与 TD2 只有长度和可选数据不同
+func (m *MRZ) parseTD23() {
+	line1, line2 := m.Lines[0], m.Lines[1]
+	m.DocumentCode = strings.TrimRight(line1[0:2], "<")
+	m.IssuingState = strings.TrimRight(line1[2:5], "<")
+	m.Surname, m.GivenNames = mrzNames(line1[5:])
+
+	m.Nationality = strings.TrimRight(line2[10:13], "<")
+	m.BirthDate = line2[13:19]
+	m.Sex = mrzSex(line2[20])
+	m.ExpiryDate = line2[21:27]
+	m.check("birth_date", m.BirthDate, line2[19])
+	m.check("expiry_date", m.ExpiryDate, line2[27])
+
+	if m.Format == "TD3" {
+		m.OptionalData = strings.TrimRight(line2[28:42], "<")
+		// 可选数据为空时校验位可以是 < 或 0
+		m.Checks["optional_data"] = MRZCheckDigit(line2[28:42]) == line2[42] || (m.OptionalData == "" && line2[42] == '<')
+	} else {
+		m.OptionalData = strings.TrimRight(line2[28:35], "<")
+	}
+
+	m.DocumentNumber = strings.TrimRight(line2[0:9], "<")
+	m.checkNumber(line2[0:9], line2[9])
+	m.Checks["composite"] = MRZCheckDigit(line2[0:10]+line2[13:20]+line2[21:len(line2)-1]) == line2[len(line2)-1]
+}
+
+func (m *MRZ) parseTD1() {
+	line1, line2 := m.Lines[0], m.Lines[1]
+	m.DocumentCode = strings.TrimRight(line1[0:2], "<")
+	m.IssuingState = strings.TrimRight(line1[2:5], "<")
+	m.Surname, m.GivenNames = mrzNames(m.Lines[2])
+
+	m.BirthDate = line2[0:6]
+	m.Sex = mrzSex(line2[7])
+	m.ExpiryDate = line2[8:14]
+	m.Nationality = strings.TrimRight(line2[15:18], "<")
+	m.OptionalData = strings.TrimRight(line1[15:30], "<")
+	m.check("birth_date", m.BirthDate, line2[6])
+	m.check("expiry_date", m.ExpiryDate, line2[14])
+
+	// 超过 9 位的文档号: 校验位处为 <, 剩余部分和校验位写在可选数据开头
+	if rest := strings.SplitN(line1[15:30], "<", 2)[0]; line1[14] == '<' && len(rest) > 1 {
+		number := line1[5:14] + rest[:len(rest)-1]
+		m.DocumentNumber = number
+		m.checkNumber(number, rest[len(rest)-1])
+		m.OptionalData = strings.TrimLeft(strings.TrimPrefix(m.OptionalData, rest), "<")
+	} else {
+		m.DocumentNumber = strings.TrimRight(line1[5:14], "<")
+		m.checkNumber(line1[5:14], line1[14])
+	}
+	m.Checks["composite"] = MRZCheckDigit(line1[5:30]+line2[0:7]+line2[8:15]+line2[18:29]) == line2[29]
+}
+
+// check 按原样校验只能是数字的字段; 失败时若把形近字母替换为数字后能通过校验, 记录为候选值
+func (m *MRZ) check(name, value string, check byte) {
+	m.Checks[name] = MRZCheckDigit(value) == check
+	if m.Checks[name] {
+		return
+	}
+	fixed, fixedCheck := mrzDigitFixes.Replace(value), mrzDigitFixes.Replace(string(check))[0]
+	if (fixed != value || fixedCheck != check) && MRZCheckDigit(fixed) == fixedCheck {
+		m.Candidates[name] = fixed
+	}
+}
+
+// checkNumber 按原样校验文档号; 失败时记录替换形近字符后唯一能通过校验的文档号作为候选值
+func (m *MRZ) checkNumber(number string, check byte) {
+	m.Checks["document_number"] = MRZCheckDigit(number) == check
+	if m.Checks["document_number"] {
+		return
+	}
+	candidate := number
+	if check = mrzDigitFixes.Replace(string(check))[0]; MRZCheckDigit(number) != check {
+		candidate = documentNumberCandidate(number, check)
+	}
+	if candidate = strings.TrimRight(candidate, "<"); candidate != "" {
+		m.Candidates["document_number"] = candidate
+	}
+}
+
+// Valid 所有校验位都通过
+func (m *MRZ) Valid() bool {
+	for _, ok := range m.Checks {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// FailedChecks 返回未通过的校验位名称
+func (m *MRZ) FailedChecks() (names []string) {
+	for _, name := range []string{"document_number", "birth_date", "expiry_date", "optional_data", "composite"} {
+		if ok, exists := m.Checks[name]; exists && !ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Birth 解析出生日期, 两位年份大于今年时视为上世纪
+func (m *MRZ) Birth() (time.Time, error) {
+	return mrzDate(m.BirthDate, DateBirth)
+}
+
+// Expiry 解析有效期, 两位年份按 DateExpiry 的规则确定世纪
+func (m *MRZ) Expiry() (time.Time, error) {
+	return mrzDate(m.ExpiryDate, DateExpiry)
+}
+
+func mrzDate(value string, kind DateKind) (time.Time, error) {
+	date, err := time.Parse("060102", value)
+	if err != nil {
+		return time.Time{}, errors.New("invalid mrz date " + value)
+	}
+	year := twoDigitYear(date.Year()%100, kind, time.Now())
+	return time.Date(year, date.Month(), date.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// mrzNames 拆分 SURNAME<<GIVEN<NAMES 形式的姓名
+func mrzNames(field string) (surname, givenNames string) {
+	parts := strings.SplitN(strings.TrimRight(field, "<"), "<<", 2)
+	surname = strings.TrimSpace(strings.ReplaceAll(parts[0], "<", " "))
+	if len(parts) == 2 {
+		givenNames = strings.TrimSpace(strings.ReplaceAll(parts[1], "<", " "))
+	}
+	return surname, givenNames
+}
+
+func mrzSex(c byte) string {
+	switch c {
+	case 'M', 'F':
+		return string(c)
+	}
+	return "X"
+}
+
+// documentNumberCandidate 互换形近字符, 在互换字符最少的组合中只有唯一一种通过校验时返回它, 否则返回空字符串
+// 总校验位覆盖文档号时权重与文档号校验相同, 无法验证猜测是否正确, 因此结果只能作为候选
+func documentNumberCandidate(number string, check byte) string {
+	var positions []int
+	for i := 0; i < len(number); i++ {
+		if _, ok := mrzConfusions[number[i]]; ok {
+			positions = append(positions, i)
+		}
+	}
+	if len(positions) == 0 || len(positions) > 8 {
+		return ""
+	}
+	for swaps := 1; swaps <= len(positions); swaps++ {
+		var found []string
+		for mask := 1; mask < 1<<len(positions); mask++ {
+			if bits.OnesCount(uint(mask)) != swaps {
+				continue
+			}
+			candidate := []byte(number)
+			for bit, i := range positions {
+				if mask&(1<<bit) != 0 {
+					candidate[i] = mrzConfusions[candidate[i]]
+				}
+			}
+			if MRZCheckDigit(string(candidate)) == check {
+				found = append(found, string(candidate))
+			}
+		}
+		if len(found) == 1 {
+			return found[0]
+		}
+		if len(found) > 1 {
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,144 @@
+package tool
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMRZCheckDigit(t *testing.T) {
+	tests := []struct {
+		value string
+		want  byte
+	}{
+		{"L898902C3", '6'},
+		{"740812", '2'},
+		{"120415", '9'},
+		{"D23145890", '7'},
+		{"ZE184226B<<<<<", '1'},
+		{"<<<<<<<<<", '0'},
+	}
+	for _, tt := range tests {
+		if got := MRZCheckDigit(tt.value); got != tt.want {
+			t.Errorf("MRZCheckDigit(%q) = %c, want %c", tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestParseMRZ ICAO 9303 第 4 至 6 部分的示例
+func TestParseMRZ(t *testing.T) {
+	tests := []struct {
+		name, text                      string
+		format, number, surname, given  string
+		birth, expiry, nationality, sex string
+		failed                          []string
+		candidates                      map[string]string
+	}{
+		{
+			name:   "TD3",
+			text:   "P<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<<<<<<<<<\nL898902C36UTO7408122F1204159ZE184226B<<<<<10",
+			format: "TD3", number: "L898902C3", surname: "ERIKSSON", given: "ANNA MARIA",
+			birth: "740812", expiry: "120415", nationality: "UTO", sex: "F",
+		},
+		{
+			name:   "TD3 with spaces and missing fillers",
+			text:   " P<UTOERIKSSON<<ANNA<MARIA \r\nL898902C36 UTO7408122F1204159ZE184226B<<<<<10",
+			format: "TD3", number: "L898902C3", surname: "ERIKSSON", given: "ANNA MARIA",
+			birth: "740812", expiry: "120415", nationality: "UTO", sex: "F",
+		},
+		{
+			name:   "TD2",
+			text:   "I<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<\nD231458907UTO7408122F1204159<<<<<<<6",
+			format: "TD2", number: "D23145890", surname: "ERIKSSON", given: "ANNA MARIA",
+			birth: "740812", expiry: "120415", nationality: "UTO", sex: "F",
+		},
+		{
+			name:   "TD1",
+			text:   "I<UTOD231458907<<<<<<<<<<<<<<<\n7408122F1204159UTO<<<<<<<<<<<6\nERIKSSON<<ANNA<MARIA<<<<<<<<<<",
+			format: "TD1", number: "D23145890", surname: "ERIKSSON", given: "ANNA MARIA",
+			birth: "740812", expiry: "120415", nationality: "UTO", sex: "F",
+		},
+		{
+			name:   "TD3 wrong birth date",
+			text:   "P<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<<<<<<<<<\nL898902C36UTO7408132F1204159ZE184226B<<<<<10",
+			format: "TD3", number: "L898902C3", surname: "ERIKSSON", given: "ANNA MARIA",
+			birth: "740813", expiry: "120415", nationality: "UTO", sex: "F",
+			failed: []string{"birth_date", "composite"},
+		},
+		{
+			// 文档号中的 C 被读成 0, 猜测的候选不能替换读到的文档号
+			name:   "TD3 misread document number",
+			text:   "P<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<<<<<<<<<\nL898902036UTO7408122F1204159ZE184226B<<<<<10",
+			format: "TD3", number: "L89890203", surname: "ERIKSSON", given: "ANNA MARIA",
+			birth: "740812", expiry: "120415", nationality: "UTO", sex: "F",
+			failed: []string{"composite", "document_number"}, candidates: map[string]string{"document_number": "L8989O2O3"},
+		},
+		{
+			// 出生日期中的 0 被读成 O, 替换后的值只作为候选, 校验不通过
+			name:   "TD3 letter in birth date",
+			text:   "P<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<<<<<<<<<\nL898902C36UTO74O8122F1204159ZE184226B<<<<<10",
+			format: "TD3", number: "L898902C3", surname: "ERIKSSON", given: "ANNA MARIA",
+			birth: "74O812", expiry: "120415", nationality: "UTO", sex: "F",
+			failed:     []string{"birth_date", "composite"},
+			candidates: map[string]string{"birth_date": "740812"},
+		},
+		{
+			name:   "TD1 letters in expiry date and check digit",
+			text:   "I<UTOD231458907<<<<<<<<<<<<<<<\n7408122F12O4159UTO<<<<<<<<<<<6\nERIKSSON<<ANNA<MARIA<<<<<<<<<<",
+			format: "TD1", number: "D23145890", surname: "ERIKSSON", given: "ANNA MARIA",
+			birth: "740812", expiry: "12O415", nationality: "UTO", sex: "F",
+			failed:     []string{"composite", "expiry_date"},
+			candidates: map[string]string{"expiry_date": "120415"},
+		},
+		{
+			name:   "TD3 letter as document number check digit",
+			text:   "P<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<<<<<<<<<\nL898902C3GUTO7408122F1204159ZE184226B<<<<<10",
+			format: "TD3", number: "L898902C3", surname: "ERIKSSON", given: "ANNA MARIA",
+			birth: "740812", expiry: "120415", nationality: "UTO", sex: "F",
+			failed:     []string{"document_number"},
+			candidates: map[string]string{"document_number": "L898902C3"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := ParseMRZ(tt.text)
+			if err != nil {
+				t.Fatalf("ParseMRZ() error = %v", err)
+			}
+			got := []string{m.Format, m.DocumentNumber, m.Surname, m.GivenNames, m.BirthDate, m.ExpiryDate, m.Nationality, m.Sex}
+			want := []string{tt.format, tt.number, tt.surname, tt.given, tt.birth, tt.expiry, tt.nationality, tt.sex}
+			if !slices.Equal(got, want) {
+				t.Errorf("ParseMRZ() = %q, want %q", got, want)
+			}
+			failed := m.FailedChecks()
+			slices.Sort(failed)
+			if !slices.Equal(failed, tt.failed) {
+				t.Errorf("FailedChecks() = %q, want %q", failed, tt.failed)
+			}
+			if m.Valid() != (len(tt.failed) == 0) {
+				t.Errorf("Valid() = %v, want %v", m.Valid(), len(tt.failed) == 0)
+			}
+			if !maps.Equal(m.Candidates, tt.candidates) {
+				t.Errorf("Candidates = %q, want %q", m.Candidates, tt.candidates)
+			}
+			// 机读区按原样返回, 不替换任何字符
+			if lines := strings.Join(m.Lines, ""); strings.ContainsAny(tt.text, "OSB") && !strings.ContainsAny(lines, "OSB") {
+				t.Errorf("Lines = %q, characters were replaced", m.Lines)
+			}
+		})
+	}
+}
+
+func TestParseMRZInvalid(t *testing.T) {
+	for _, text := range []string{
+		"",
+		"P<UTOERIKSSON<<ANNA<MARIA",
+		"P<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<<<<<<<<<<<<\nL898902C36UTO7408122F1204159ZE184226B<<<<<10",
+		"P<UTOERIKSSON<<ANNA<MARIA<<<<<<<<<<<<<<<<<<<\nL898902C36UTO7408122F1204159ZE184226B<<<<?10",
+	} {
+		if _, err := ParseMRZ(text); err == nil {
+			t.Errorf("ParseMRZ(%q) error = nil, want error", text)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package tool
+
+import (
+	"fmt"
+	"math"
+	"net/mail"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/gogf/gf/v2/util/gconv"
+)
+
+// ValidateSchema 按 JSON Schema 的常用关键字校验 value, 返回所有不符合的位置
+// 支持 type, properties, required, additionalProperties, items, enum, pattern,
+// format (date, date-time, email), minLength, maxLength, minimum, maximum, minItems, maxItems
+func ValidateSchema(value interface{}, schema map[string]interface{}) (violations []string) {
+	return validateSchema("$", value, schema)
+}
+
+func validateSchema(path string, value interface{}, schema map[string]interface{}) (violations []string) {
+	if schemaType, ok := schema["type"]; ok && !matchType(value, schemaType) {
+		return []string{fmt.Sprintf("%s: expect type %v, got %s", path, schemaType, jsonType(value))}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		matched := false
+		for _, item := range enum {
+			if fmt.Sprint(item) == fmt.Sprint(value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			violations = append(violations, fmt.Sprintf("%s: %v is not one of %v", path, value, enum))
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		violations = append(violations, validateString(path, v, schema)...)
+	case float64:
+		if minimum, ok := schemaNumber(schema, "minimum"); ok && v < minimum {
+			violations = append(violations, fmt.Sprintf("%s: %v is less than minimum %v", path, v, minimum))
+		}
+		if maximum, ok := schemaNumber(schema, "maximum"); ok && v > maximum {
+			violations = append(violations, fmt.Sprintf("%s: %v is greater than maximum %v", path, v, maximum))
+		}
+	case []interface{}:
+		if minItems, ok := schemaNumber(schema, "minItems"); ok && float64(len(v)) < minItems {
+			violations = append(violations, fmt.Sprintf("%s: expect at least %v items, got %d", path, minItems, len(v)))
+		}
+		if maxItems, ok := schemaNumber(schema, "maxItems"); ok && float64(len(v)) > maxItems {
+			violations = append(violations, fmt.Sprintf("%s: expect at most %v items, got %d", path, maxItems, len(v)))
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				violations = append(violations, validateSchema(fmt.Sprintf("%s[%d]", path, i), item, items)...)
+			}
+		}
+	case map[string]interface{}:
+		violations = append(violations, validateObject(path, v, schema)...)
+	}
+	return violations
+}
+
+func validateString(path, value string, schema map[string]interface{}) (violations []string) {
+	length := float64(len([]rune(value)))
+	if minLength, ok := schemaNumber(schema, "minLength"); ok && length < minLength {
+		violations = append(violations, fmt.Sprintf("%s: expect at least %v characters", path, minLength))
+	}
+	if maxLength, ok := schemaNumber(schema, "maxLength"); ok && length > maxLength {
+		violations = append(violations, fmt.Sprintf("%s: expect at most %v characters", path, maxLength))
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("%s: invalid pattern %s", path, pattern))
+		} else if !re.MatchString(value) {
+			violations = append(violations, fmt.Sprintf("%s: %q does not match pattern %s", path, value, pattern))
+		}
+	}
+	var formatErr error
+	switch schema["format"] {
+	case "date":
+		_, formatErr = time.Parse("2006-01-02", value)
+	case "date-time":
+		_, formatErr = time.Parse(time.RFC3339, value)
+	case "email":
+		_, formatErr = mail.ParseAddress(value)
+	}
+	if formatErr != nil {
+		violations = append(violations, fmt.Sprintf("%s: %q is not a valid %v", path, value, schema["format"]))
+	}
+	return violations
+}
+
+// schemaNumber 读取 schema 中的数值关键字, 请求中的 schema 由 GoFrame 解码为 json.Number, 自行解码时为 float64
+func schemaNumber(schema map[string]interface{}, key string) (float64, bool) {
+	value, ok := schema[key]
+	if !ok || value == nil {
+		return 0, false
+	}
+	return gconv.Float64(value), true
+}
+
+func validateObject(path string, value, schema map[string]interface{}) (violations []string) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, name := range required {
+			if _, ok := value[fmt.Sprint(name)]; !ok {
+				violations = append(violations, fmt.Sprintf("%s.%v: is required", path, name))
+			}
+		}
+	}
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		propertySchema, ok := properties[key].(map[string]interface{})
+		if !ok {
+			if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+				violations = append(violations, fmt.Sprintf("%s.%s: is not allowed", path, key))
+			}
+			continue
+		}
+		violations = append(violations, validateSchema(path+"."+key, value[key], propertySchema)...)
+	}
+	return violations
+}
+
+func matchType(value interface{}, schemaType interface{}) bool {
+	if types, ok := schemaType.([]interface{}); ok {
+		for _, item := range types {
+			if matchType(value, item) {
+				return true
+			}
+		}
+		return false
+	}
+	actual := jsonType(value)
+	if schemaType == "integer" {
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	}
+	return actual == schemaType || (schemaType == "number" && actual == "integer")
+}
+
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// DropNulls 递归删除对象中值为 null 的字段, 模型常用 null 表示图片中没有的可选字段
+func DropNulls(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if item == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = DropNulls(item)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = DropNulls(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,109 @@
+package tool
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestValidateSchemaJSONNumber 请求中的 schema 解码后数值关键字为 json.Number, 仍需生效
+func TestValidateSchemaJSONNumber(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`{"type": "object", "properties": {
+		"name": {"type": "string", "minLength": 3, "maxLength": 10},
+		"age": {"type": "integer", "minimum": 0, "maximum": 120},
+		"score": {"type": "number", "minimum": 0.5},
+		"tags": {"type": "array", "minItems": 1}}}`))
+	decoder.UseNumber()
+	var schema map[string]interface{}
+	if err := decoder.Decode(&schema); err != nil {
+		t.Fatal(err)
+	}
+	output := map[string]interface{}{"name": "AB", "age": float64(150), "score": float64(0.2), "tags": []interface{}{}}
+	want := []string{
+		"$.age: 150 is greater than maximum 120",
+		"$.name: expect at least 3 characters",
+		"$.score: 0.2 is less than minimum 0.5",
+		"$.tags: expect at least 1 items, got 0",
+	}
+	if got := ValidateSchema(output, schema); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ValidateSchema() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	schema := map[string]interface{}{
+		"type":                 "object",
+		"required":             []interface{}{"name", "birth_date"},
+		"additionalProperties": false,
+		"properties": map[string]interface{}{
+			"name":       map[string]interface{}{"type": "string", "minLength": float64(2), "maxLength": float64(10)},
+			"birth_date": map[string]interface{}{"type": "string", "format": "date"},
+			"email":      map[string]interface{}{"type": "string", "format": "email"},
+			"sex":        map[string]interface{}{"type": "string", "enum": []interface{}{"M", "F"}},
+			"code":       map[string]interface{}{"type": "string", "pattern": `^[A-Z]{3}$`},
+			"age":        map[string]interface{}{"type": "integer", "minimum": float64(0), "maximum": float64(150)},
+			"score":      map[string]interface{}{"type": []interface{}{"number", "null"}},
+			"tags": map[string]interface{}{
+				"type": "array", "minItems": float64(1), "maxItems": float64(2),
+				"items": map[string]interface{}{"type": "string"},
+			},
+		},
+	}
+	tests := []struct {
+		name  string
+		value map[string]interface{}
+		want  []string
+	}{
+		{
+			name: "valid",
+			value: map[string]interface{}{
+				"name": "张三", "birth_date": "1996-06-08", "email": "a@example.com", "sex": "F",
+				"code": "CHN", "age": float64(30), "score": 9.5, "tags": []interface{}{"a"},
+			},
+		},
+		{
+			name:  "null allowed by type list",
+			value: map[string]interface{}{"name": "AB", "birth_date": "1996-06-08", "score": nil},
+		},
+		{
+			name:  "missing required",
+			value: map[string]interface{}{"name": "AB"},
+			want:  []string{"$.birth_date: is required"},
+		},
+		{
+			name: "constraint violations",
+			value: map[string]interface{}{
+				"name": "A", "birth_date": "08/06/1996", "email": "not an email", "sex": "U",
+				"code": "CN", "age": float64(30.5), "tags": []interface{}{"a", "b", float64(1)}, "extra": true,
+			},
+			want: []string{
+				`$.age: expect type integer, got number`,
+				`$.birth_date: "08/06/1996" is not a valid date`,
+				`$.code: "CN" does not match pattern ^[A-Z]{3}$`,
+				`$.email: "not an email" is not a valid email`,
+				`$.extra: is not allowed`,
+				`$.name: expect at least 2 characters`,
+				`$.sex: U is not one of [M F]`,
+				`$.tags: expect at most 2 items, got 3`,
+				`$.tags[2]: expect type string, got integer`,
+			},
+		},
+		{
+			name:  "range",
+			value: map[string]interface{}{"name": "ABCDEFGHIJK", "birth_date": "1996-06-08", "age": float64(-1), "tags": []interface{}{}},
+			want: []string{
+				"$.age: -1 is less than minimum 0",
+				"$.name: expect at most 10 characters",
+				"$.tags: expect at least 1 items, got 0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ValidateSchema(tt.value, schema)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("ValidateSchema() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package tool
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+type tokenHandlerKey struct{}
+
+// WithTokenHandler 在 ctx 中注册流式输出回调, 平台实现检测到后改用 stream 模式请求
+func WithTokenHandler(ctx context.Context, handler func(token string)) context.Context {
+	return context.WithValue(ctx, tokenHandlerKey{}, handler)
+}
+
+// TokenHandler 返回 ctx 中注册的流式输出回调, 未注册时返回 nil
+func TokenHandler(ctx context.Context) func(token string) {
+	handler, _ := ctx.Value(tokenHandlerKey{}).(func(token string))
+	return handler
+}
+
+// EnableStream 在 chat/completions 请求体中打开 stream 参数
+func EnableStream(reqBody string) (string, error) {
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(reqBody), &body); err != nil {
+		return "", err
+	}
+	body["stream"] = true
+	streamBody, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	return string(streamBody), nil
+}
+
+type chatStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+		FinishReason string `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// ReadChatStream 读取 chat/completions 的 SSE 响应, 每收到一段文本调用一次 onToken
+// 返回拼接后的完整文本和最后一个 finish_reason
+func ReadChatStream(body io.Reader, onToken func(token string)) (content, finishReason string, err error) {
+	var builder strings.Builder
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+		var chunk chatStreamChunk
+		if err = json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", "", err
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content != "" {
+				builder.WriteString(choice.Delta.Content)
+				onToken(choice.Delta.Content)
+			}
+			if choice.FinishReason != "" {
+				finishReason = choice.FinishReason
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", "", err
+	}
+	return builder.String(), finishReason, nil
+}
@@ -0,0 +1,83 @@
+package tool
+
+import (
+	"strings"
+	"unicode"
+)
+
+// icaoTransliterations ICAO 9303 第 3 部分推荐的转写表, 包括带变音符号的拉丁字母、西里尔字母、希腊字母和阿拉伯字母
+// 阿拉伯字母使用可逆转写, 以 X 开头的组合表示单个字母
+var icaoTransliterations = map[rune]string{
+	// 拉丁字母
+	'Á': "A", 'À': "A", 'Â': "A", 'Ä': "AE", 'Ã': "A", 'Å': "AA", 'Ā': "A", 'Ă': "A", 'Ą': "A", 'Æ': "AE",
+	'Ç': "C", 'Ć': "C", 'Ĉ': "C", 'Ċ': "C", 'Č': "C", 'Ď': "D", 'Đ': "D", 'Ð': "D",
+	'É': "E", 'È': "E", 'Ê': "E", 'Ë': "E", 'Ē': "E", 'Ĕ': "E", 'Ė': "E", 'Ę': "E", 'Ě': "E",
+	'Ĝ': "G", 'Ğ': "G", 'Ġ': "G", 'Ģ': "G", 'Ĥ': "H", 'Ħ': "H",
+	'Í': "I", 'Ì': "I", 'Î': "I", 'Ï': "I", 'Ĩ': "I", 'Ī': "I", 'Ĭ': "I", 'Į': "I", 'İ': "I", 'Ĳ': "IJ",
+	'Ĵ': "J", 'Ķ': "K", 'Ĺ': "L", 'Ļ': "L", 'Ľ': "L", 'Ŀ': "L", 'Ł': "L",
+	'Ñ': "N", 'Ń': "N", 'Ņ': "N", 'Ň': "N", 'Ŋ': "N",
+	'Ó': "O", 'Ò': "O", 'Ô': "O", 'Ö': "OE", 'Õ': "O", 'Ō': "O", 'Ŏ': "O", 'Ő': "O", 'Ø': "OE", 'Œ': "OE",
+	'Ŕ': "R", 'Ŗ': "R", 'Ř': "R", 'Ś': "S", 'Ŝ': "S", 'Ş': "S", 'Ș': "S", 'Š': "S", 'ẞ': "SS",
+	'Ţ': "T", 'Ț': "T", 'Ť': "T", 'Ŧ': "T", 'Þ': "TH",
+	'Ú': "U", 'Ù': "U", 'Û': "U", 'Ü': "UE", 'Ũ': "U", 'Ū': "U", 'Ŭ': "U", 'Ů': "U", 'Ű': "U", 'Ų': "U",
+	'Ŵ': "W", 'Ý': "Y", 'Ÿ': "Y", 'Ŷ': "Y", 'Ź': "Z", 'Ż': "Z", 'Ž': "Z",
+
+	// 西里尔字母
+	'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Ґ': "G", 'Д': "D", 'Ѓ': "G", 'Ђ': "D", 'Е': "E", 'Ё': "E", 'Є': "IE",
+	'Ж': "ZH", 'З': "Z", 'Ѕ': "DZ", 'И': "I", 'І': "I", 'Ї': "I", 'Й': "I", 'Ј': "J", 'К': "K", 'Л': "L", 'Љ': "LJ",
+	'М': "M", 'Н': "N", 'Њ': "NJ", 'О': "O", 'П': "P", 'Р': "R", 'С': "S", 'Т': "T", 'Ћ': "C", 'Ќ': "K", 'У': "U",
+	'Ў': "U", 'Ф': "F", 'Х': "KH", 'Ц': "TS", 'Ч': "CH", 'Џ': "DZ", 'Ш': "SH", 'Щ': "SHCH", 'Ъ': "IE", 'Ы': "Y",
+	'Ь': "", 'Э': "E", 'Ю': "IU", 'Я': "IA",
+
+	// 希腊字母
+	'Α': "A", 'Ά': "A", 'Β': "V", 'Γ': "G", 'Δ': "D", 'Ε': "E", 'Έ': "E", 'Ζ': "Z", 'Η': "I", 'Ή': "I", 'Θ': "TH",
+	'Ι': "I", 'Ί': "I", 'Ϊ': "I", 'Κ': "K", 'Λ': "L", 'Μ': "M", 'Ν': "N", 'Ξ': "X", 'Ο': "O", 'Ό': "O", 'Π': "P",
+	'Ρ': "R", 'Σ': "S", 'Τ': "T", 'Υ': "Y", 'Ύ': "Y", 'Ϋ': "Y", 'Φ': "F", 'Χ': "CH", 'Ψ': "PS", 'Ω': "O", 'Ώ': "O",
+
+	// 阿拉伯字母
+	'ء': "XE", 'آ': "XAA", 'أ': "XAE", 'ؤ': "U", 'إ': "I", 'ئ': "XI", 'ا': "A", 'ب': "B", 'ة': "XTA", 'ت': "T",
+	'ث': "XTH", 'ج': "J", 'ح': "XH", 'خ': "XKH", 'د': "D", 'ذ': "XDH", 'ر': "R", 'ز': "Z", 'س': "S", 'ش': "XSH",
+	'ص': "XSS", 'ض': "XDZ", 'ط': "XTT", 'ظ': "XZZ", 'ع': "E", 'غ': "G", 'ف': "F", 'ق': "Q", 'ك': "K", 'ل': "L",
+	'م': "M", 'ن': "N", 'ه': "H", 'و': "W", 'ى': "XAY", 'ي': "Y", 'ً': "AN", 'ٌ': "UN", 'ٍ': "IN", 'َ': "A",
+	'ُ': "U", 'ِ': "I", 'ْ': "", 'ـ': "",
+}
+
+// TransliterateICAO 按 ICAO 9303 将姓名转写为大写拉丁字母, 空格、连字符和撇号保持不变
+// 阿拉伯文的叠音符号重复前一个字母的转写, 其他组合变音符号直接去掉
+// 含有转写表之外的文字 (例如汉字、假名、谚文) 时 complete 为 false, 这些字符原样保留
+func TransliterateICAO(value string) (latin string, complete bool) {
+	complete = true
+	var builder strings.Builder
+	letter := "" // 上一个字母的转写
+	marks := ""  // 上一个字母之后的元音符号, 叠音符号可能出现在元音符号之后, 重复的字母要写在元音之前
+	for _, r := range strings.TrimSpace(value) {
+		upper := unicode.ToUpper(r)
+		mapped, ok := icaoTransliterations[upper]
+		switch {
+		case upper == '\u0651': // shadda
+			builder.WriteString(letter)
+			continue
+		case upper >= 'A' && upper <= 'Z', upper == '-', upper == '\'':
+			mapped = string(upper)
+		case unicode.IsSpace(upper):
+			mapped = " "
+		case upper == 'ß':
+			mapped = "SS"
+		case ok:
+		case unicode.Is(unicode.Mn, r):
+			mapped = ""
+		default:
+			complete = false
+			mapped = string(r)
+		}
+		if unicode.Is(unicode.Mn, r) {
+			marks += mapped
+			continue
+		}
+		builder.WriteString(marks)
+		builder.WriteString(mapped)
+		letter, marks = mapped, ""
+	}
+	builder.WriteString(marks)
+	return builder.String(), complete
+}
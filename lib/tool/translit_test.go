@@ -0,0 +1,36 @@
+package tool
+
+import "testing"
+
+// TestTransliterateICAO 预期值来自 ICAO 9303 第 3 部分的转写表
+func TestTransliterateICAO(t *testing.T) {
+	tests := []struct {
+		value, want string
+		complete    bool
+	}{
+		{"Müller", "MUELLER", true},
+		{"Gößmann", "GOESSMANN", true},
+		{"Ångström", "AANGSTROEM", true},
+		{"José Núñez", "JOSE NUNEZ", true},
+		{"Łukasz Żółć", "LUKASZ ZOLC", true},
+		{"Jean-Pierre O'Neil", "JEAN-PIERRE O'NEIL", true},
+		{"Иванов Пётр", "IVANOV PETR", true},
+		{"Щукина Юлия", "SHCHUKINA IULIIA", true},
+		{"Андрійович", "ANDRIIOVICH", true},
+		{"Παπαδόπουλος", "PAPADOPOYLOS", true},
+		{"Θεοδωρος Χατζης", "THEODOROS CHATZIS", true},
+		{"سعيد", "SEYD", true},
+		{"\u0645\u064f\u062d\u064e\u0645\u064e\u0651\u062f", "MUXHAMMAD", true}, // 叠音符号在元音符号之后
+		{"\u0645\u064f\u062d\u064e\u0645\u0651\u064e\u062f", "MUXHAMMAD", true}, // 叠音符号在元音符号之前
+		{"  Анна  ", "ANNA", true},
+		// 转写表之外的文字原样保留
+		{"王小明", "王小明", false},
+		{"Kim 김", "KIM 김", false},
+	}
+	for _, tt := range tests {
+		got, complete := TransliterateICAO(tt.value)
+		if got != tt.want || complete != tt.complete {
+			t.Errorf("TransliterateICAO(%q) = %q, %v, want %q, %v", tt.value, got, complete, tt.want, tt.complete)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package tool
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, [][]string{{"名称", "备注"}, {"苹果", "红色, 大"}, {"梨", "第一行\n\"第二行\""}}); err != nil {
+		t.Fatal(err)
+	}
+	want := "\ufeff名称,备注\n苹果,\"红色, 大\"\n梨,\"第一行\n\"\"第二行\"\"\"\n"
+	if buf.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestWriteXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	sheets := []XLSXSheet{
+		{Name: "销量 [2024]", Rows: [][]string{{"地区", "数量"}, {"华东", ""}, {"A&B", "<1>"}}},
+		{Name: "销量 [2024]"},
+		{Name: strings.Repeat("长", 40)},
+	}
+	if err := WriteXLSX(&buf, sheets); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		files[file.Name] = string(content)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml", "xl/worksheets/sheet3.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("WriteXLSX() is missing %s", name)
+		}
+	}
+	workbook := files["xl/workbook.xml"]
+	for _, want := range []string{`<sheet name="销量 (2024)" sheetId="1"`, `<sheet name="Sheet2" sheetId="2"`, `<sheet name="` + strings.Repeat("长", 31) + `" sheetId="3"`} {
+		if !strings.Contains(workbook, want) {
+			t.Errorf("workbook.xml does not contain %s", want)
+		}
+	}
+	sheet := files["xl/worksheets/sheet1.xml"]
+	for _, want := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">地区</t></is></c>`,
+		`<row r="2"><c r="A2" t="inlineStr"><is><t xml:space="preserve">华东</t></is></c></row>`,
+		`<c r="B3" t="inlineStr"><is><t xml:space="preserve">&lt;1&gt;</t></is></c>`,
+		`A&amp;B`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet1.xml does not contain %s", want)
+		}
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"}
+	for index, want := range tests {
+		if got := columnName(index); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", index, got, want)
+		}
+	}
+}
@@ -1,10 +1,18 @@
 package main
 
 import (
+	"bytes"
 	"codeocr/api"
 	"codeocr/lib/ocr"
+	"codeocr/lib/tool"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 
+	"github.com/gogf/gf/v2/errors/gcode"
+	"github.com/gogf/gf/v2/errors/gerror"
 	"github.com/gogf/gf/v2/frame/g"
 	"github.com/gogf/gf/v2/net/ghttp"
 	"github.com/gogf/gf/v2/os/gcfg"
@@ -16,7 +24,13 @@ type Ocr struct{}
 func (Ocr) OcrHandler(ctx context.Context, req *api.OcrReq) (res *api.OcrRes, err error) {
 
 	serv := ocr.NewOcr(req.Platform)
-	resp, err := serv.ImageNumber(ctx, req.Content, req.Model)
+	switch req.Mode {
+	case "text":
+		return ocr.ImageText(ctx, serv, req.Content, req.Model, req.LanguageTags)
+	case "all":
+		return ocr.ImageNumbers(ctx, serv, req.Content, req.Model)
+	}
+	resp, err := ocr.ImageNumber(ctx, serv, req.Content, req.Model, codeOptions(req))
 	if err != nil {
 		return nil, err
 	}
@@ -26,50 +40,308 @@ func (Ocr) OcrHandler(ctx context.Context, req *api.OcrReq) (res *api.OcrRes, er
 	return res, nil
 }
 
+func codeOptions(req *api.OcrReq) ocr.CodeOptions {
+	return ocr.CodeOptions{
+		Charset:       req.Charset,
+		CaseSensitive: req.CaseSensitive,
+		Length:        req.Length,
+		MinLength:     req.MinLength,
+		MaxLength:     req.MaxLength,
+		Pattern:       req.Pattern,
+	}
+}
+
 func (Ocr) PassportHandler(ctx context.Context, req *api.OcrPassportReq) (resp *api.OcrPassportRes, err error) {
 
 	serv := ocr.NewOcr(req.Platform)
-	passportInfo, err := serv.PassportInfo(ctx, req.Content, req.Model)
+	return ocr.PassportInfo(ctx, serv, req.Content, req.Model, ocr.DocumentOptions{Positions: req.Positions, Extended: req.Schema == "full", Profile: req.OutputProfile})
+}
+
+func (Ocr) DrivingLicenseHandler(ctx context.Context, req *api.OcrDrivingLicenseReq) (resp *api.OcrDrivingLicenseRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	return ocr.DrivingLicenseInfo(ctx, serv, req.Content, req.Model, ocr.DocumentOptions{Language: req.Language, Positions: req.Positions, Profile: req.OutputProfile})
+}
+
+func (Ocr) IdCardHandler(ctx context.Context, req *api.OcrIdCardReq) (resp *api.OcrIdCardRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	idCardInfo, warnings, err := ocr.IdCardInfo(ctx, serv, req.Content, req.Model, req.Side)
 	if err != nil {
 		return nil, err
 	}
-	resp = &api.OcrPassportRes{
-		PassportInfo: passportInfo,
+	validity, _, validityWarnings := ocr.IdCardValidity(idCardInfo)
+	resp = &api.OcrIdCardRes{
+		IdCardInfo: idCardInfo,
+		Validity:   validity,
+		Warnings:   append(warnings, validityWarnings...),
 	}
 	return resp, nil
+}
 
+func (Ocr) VehicleLicenseHandler(ctx context.Context, req *api.OcrVehicleLicenseReq) (resp *api.OcrVehicleLicenseRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	vehicleLicenseInfo, err := ocr.VehicleLicenseInfo(ctx, serv, req.Content, req.Model)
+	if err != nil {
+		return nil, err
+	}
+	resp = &api.OcrVehicleLicenseRes{
+		VehicleLicenseInfo: vehicleLicenseInfo,
+	}
+	return resp, nil
 }
 
-func (Ocr) DrivingLicenseHandler(ctx context.Context, req *api.OcrDrivingLicenseReq) (resp *api.OcrDrivingLicenseRes, err error) {
+func (Ocr) BankCardHandler(ctx context.Context, req *api.OcrBankCardReq) (resp *api.OcrBankCardRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	bankCardInfo, warnings, err := ocr.BankCardInfo(ctx, serv, req.Content, req.Model)
+	if err != nil {
+		return nil, err
+	}
+	resp = &api.OcrBankCardRes{
+		BankCardInfo: bankCardInfo,
+		Warnings:     warnings,
+	}
+	return resp, nil
+}
+
+func (Ocr) BusinessLicenseHandler(ctx context.Context, req *api.OcrBusinessLicenseReq) (resp *api.OcrBusinessLicenseRes, err error) {
 
 	serv := ocr.NewOcr(req.Platform)
-	drivingLicenseInfo, err := serv.DrivingLicenseInfo(ctx, req.Content, req.Model, req.Language)
+	businessLicenseInfo, warnings, err := ocr.BusinessLicenseInfo(ctx, serv, req.Content, req.Model)
 	if err != nil {
 		return nil, err
 	}
-	resp = &api.OcrDrivingLicenseRes{
-		DrivingLicenseInfo: drivingLicenseInfo,
+	resp = &api.OcrBusinessLicenseRes{
+		BusinessLicenseInfo: businessLicenseInfo,
+		Warnings:            warnings,
 	}
+	return resp, nil
+}
 
+func (Ocr) InvoiceHandler(ctx context.Context, req *api.OcrInvoiceReq) (resp *api.OcrInvoiceRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	invoiceInfo, warnings, err := ocr.InvoiceInfo(ctx, serv, req.Content, req.Model)
+	if err != nil {
+		return nil, err
+	}
+	resp = &api.OcrInvoiceRes{
+		InvoiceInfo: invoiceInfo,
+		Warnings:    warnings,
+	}
 	return resp, nil
 }
 
+func (Ocr) ExtractHandler(ctx context.Context, req *api.OcrExtractReq) (resp *api.OcrExtractRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	result, err := ocr.Extract(ctx, serv, req)
+	if err != nil {
+		return nil, err
+	}
+	resp = &api.OcrExtractRes{
+		Result: result,
+	}
+	return resp, nil
+}
+
+func (Ocr) DocumentHandler(ctx context.Context, req *api.OcrDocumentReq) (resp *api.OcrDocumentRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	result, err := ocr.RecognizeDocument(ctx, serv, req.Type, req.Content, req.Model, ocr.DocumentOptions{Language: req.Language, Positions: req.Positions, Extended: req.Schema == "full", Profile: req.OutputProfile})
+	if err != nil {
+		return nil, err
+	}
+	resp = &api.OcrDocumentRes{
+		DocumentType: req.Type,
+		Fields:       result.Fields,
+		Positions:    result.Positions,
+		Validity:     result.Validity,
+		Translations: result.Translations,
+		Warnings:     result.Warnings,
+	}
+	return resp, nil
+}
+
+func (Ocr) TableHandler(ctx context.Context, req *api.OcrTableReq) (resp *api.OcrTableRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	tables, err := ocr.Tables(ctx, serv, req.Content, req.Model)
+	if err != nil {
+		return nil, err
+	}
+	resp = &api.OcrTableRes{
+		Tables: tables,
+	}
+	return resp, nil
+}
+
+func (Ocr) ClassifyHandler(ctx context.Context, req *api.OcrClassifyReq) (resp *api.OcrClassifyRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	classification, err := ocr.ClassifyDocument(ctx, serv, req.Content, req.Model)
+	if err != nil {
+		return nil, err
+	}
+	resp = &api.OcrClassifyRes{
+		ClassifyInfo: classification,
+	}
+	return resp, nil
+}
+
+func (Ocr) AutoHandler(ctx context.Context, req *api.OcrAutoReq) (resp *api.OcrAutoRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	return ocr.AutoRecognize(ctx, serv, req)
+}
+
+func (Ocr) VerifyAgeHandler(ctx context.Context, req *api.VerifyAgeReq) (resp *api.VerifyAgeRes, err error) {
+
+	serv := ocr.NewOcr(req.Platform)
+	return ocr.VerifyAge(ctx, serv, req.Content, req.Model, req.Threshold)
+}
+
+// TableDownloadHandler 以文件形式返回识别出的表格, csv 只包含 table 指定的一个表格, xlsx 每个表格一个工作表
+func TableDownloadHandler(r *ghttp.Request) {
+	var req *api.OcrTableDownloadReq
+	if err := r.Parse(&req); err != nil {
+		r.Response.WriteJson(api.Response{Message: err.Error()})
+		return
+	}
+	tables, err := ocr.Tables(r.Context(), ocr.NewOcr(req.Platform), req.Content, req.Model)
+	if err != nil {
+		writeError(r, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	switch req.Format {
+	case "xlsx":
+		err = tool.WriteXLSX(&buf, ocr.TableSheets(tables))
+		r.Response.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	default:
+		if req.Table >= len(tables) {
+			writeError(r, fmt.Errorf("table %d not found, %d tables detected", req.Table, len(tables)))
+			return
+		}
+		err = tool.WriteCSV(&buf, tables[req.Table].Rows)
+		r.Response.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	}
+	if err != nil {
+		writeError(r, err)
+		return
+	}
+	r.Response.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="tables.%s"`, req.Format))
+	r.Response.Write(buf.Bytes())
+}
+
+// writeError 以统一的 JSON 格式返回错误
+func writeError(r *ghttp.Request, err error) {
+	res := api.Response{Message: err.Error()}
+	var outputErr *tool.OutputError
+	if errors.As(err, &outputErr) {
+		res.Detail = outputErr
+	}
+	r.Response.WriteJson(res)
+}
+
+// sseEmitter 将识别事件以 Server-Sent Events 格式逐条写给客户端
+func sseEmitter(r *ghttp.Request) ocr.EmitFunc {
+	r.Response.Header().Set("Content-Type", "text/event-stream")
+	r.Response.Header().Set("Cache-Control", "no-cache")
+	r.Response.Header().Set("Connection", "keep-alive")
+	return func(event string, data interface{}) {
+		payload, err := json.Marshal(data)
+		if err != nil {
+			g.Log().Errorf(r.Context(), "sse marshal: %s", err.Error())
+			return
+		}
+		r.Response.Writef("event: %s\ndata: %s\n\n", event, payload)
+		r.Response.Flush()
+	}
+}
+
+// OcrStreamHandler 以 SSE 推送 /ocr 的识别过程: started, token, 最后是 result 或 error, 不推送 field 事件
+func OcrStreamHandler(r *ghttp.Request) {
+	var req *api.OcrReq
+	if err := r.Parse(&req); err != nil {
+		r.Response.WriteJson(api.Response{Message: err.Error()})
+		return
+	}
+	switch req.Mode {
+	case "text":
+		ocr.StreamImageText(r.Context(), req.Platform, req.Content, req.Model, req.LanguageTags, sseEmitter(r))
+		return
+	case "all":
+		ocr.StreamImageNumbers(r.Context(), req.Platform, req.Content, req.Model, sseEmitter(r))
+		return
+	}
+	ocr.StreamImageNumber(r.Context(), req.Platform, req.Content, req.Model, codeOptions(req), sseEmitter(r))
+}
+
+// PassportStreamHandler 以 SSE 推送护照识别过程, 除 /ocr/stream 的事件外, 模型输出完整一个字段后推送 field 事件
+// field 事件目前只有护照推送, 其他按模板识别的证件没有流式接口
+func PassportStreamHandler(r *ghttp.Request) {
+	var req *api.OcrPassportReq
+	if err := r.Parse(&req); err != nil {
+		r.Response.WriteJson(api.Response{Message: err.Error()})
+		return
+	}
+	ocr.StreamPassportInfo(r.Context(), req.Platform, req.Content, req.Model, ocr.DocumentOptions{Positions: req.Positions, Extended: req.Schema == "full", Profile: req.OutputProfile}, sseEmitter(r))
+}
+
+// internalErrorMessage 替代 panic 信息返回给客户端, 原始错误只写入日志
+const internalErrorMessage = "internal error"
+
+// recoveredPanic 框架会捕获 handler 中的 panic, 以 gcode.CodeInternalPanic 记为请求错误并把异常信息写入响应缓冲区
+func recoveredPanic(r *ghttp.Request) bool {
+	err := r.GetError()
+	if err == nil || gerror.Code(err) != gcode.CodeInternalPanic {
+		return false
+	}
+	g.Log().Errorf(r.Context(), "panic: %+v", err)
+	r.Response.ClearBuffer()
+	return true
+}
+
+// Recovery 流式接口和文件下载接口不经过 Middleware, panic 时同样只返回通用的错误信息
+func Recovery(r *ghttp.Request) {
+	r.Middleware.Next()
+	if recoveredPanic(r) {
+		r.Response.WriteHeader(http.StatusInternalServerError)
+		r.Response.WriteJson(api.Response{
+			Message: internalErrorMessage,
+		})
+	}
+}
+
 func Middleware(r *ghttp.Request) {
 	r.Middleware.Next()
 
 	var (
-		msg string
-		res = r.GetHandlerResponse()
-		err = r.GetError()
+		msg    string
+		detail interface{}
+		res    = r.GetHandlerResponse()
+		err    = r.GetError()
 	)
-	if err != nil {
+	if recoveredPanic(r) {
+		msg = internalErrorMessage
+	} else if err != nil {
 		msg = err.Error()
+		var outputErr *tool.OutputError
+		if errors.As(err, &outputErr) {
+			detail = outputErr
+		}
+		r.Response.ClearBuffer()
 	} else {
 		msg = "OK"
 	}
 	r.Response.WriteJson(api.Response{
 		Message: msg,
 		Data:    res,
+		Detail:  detail,
 	})
 }
 
@@ -81,6 +353,13 @@ func main() {
 			new(Ocr),
 		)
 	})
+	// 流式接口直接写 SSE, 文件下载接口直接写文件, 不经过统一 JSON 响应中间件
+	s.Group("/", func(group *ghttp.RouterGroup) {
+		group.Middleware(Recovery)
+		group.POST("/ocr/stream", OcrStreamHandler)
+		group.POST("/ocr/passport/stream", PassportStreamHandler)
+		group.POST("/ocr/table/download", TableDownloadHandler)
+	})
 
 	ctx := gctx.New()
 	adapter, err := gcfg.NewAdapterFile("config")